@@ -0,0 +1,204 @@
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultOutParameterSize is the default max size of the string and []byte OUT and IN OUT parameters,
+// which is the max length of VARCHAR2 and RAW in SQL without MAX_STRING_SIZE = EXTENDED
+const DefaultOutParameterSize = 4000
+
+// ParameterMode is the mode of the parameters of stored procedures and functions
+type ParameterMode int
+
+const (
+	ParamIn ParameterMode = iota
+	ParamOut
+	ParamInOut
+)
+
+// String returns the mode in PL/SQL, e.g. IN OUT
+func (m ParameterMode) String() string {
+	switch m {
+	case ParamOut:
+		return "OUT"
+	case ParamInOut:
+		return "IN OUT"
+	default:
+		return "IN"
+	}
+}
+
+// Parameter is a parameter of stored procedures and functions, see Call
+type Parameter struct {
+	// Name is the name of the parameter for the named notation, e.g. P_ID => :1,
+	// the parameter is passed by position if it's empty
+	Name string
+	// Value is the value of IN parameter, or the pointer receives the value of OUT and IN OUT parameters.
+	// The slices of IN parameters are bound as PL/SQL associative arrays, e.g. InParam([]int{1, 2, 3})
+	Value interface{}
+	Mode  ParameterMode
+	// Size is the max size of string and []byte OUT and IN OUT parameters, in characters for strings
+	// and bytes for []byte, defaulting to DefaultOutParameterSize. go-ora sizes the buffer by the value
+	// passed in otherwise, which fails with ORA-06502 when the returned value is longer. It's the max number
+	// of elements for the slices of PL/SQL associative arrays, and it's ignored for the other types.
+	Size int
+}
+
+// In returns an IN parameter of the value
+func InParam(value interface{}) Parameter {
+	return Parameter{Value: value, Mode: ParamIn}
+}
+
+// Out returns an OUT parameter, dest must be a pointer which receives the value
+func OutParam(dest interface{}) Parameter {
+	return Parameter{Value: dest, Mode: ParamOut}
+}
+
+// InOut returns an IN OUT parameter, dest must be a pointer which holds the value passed in and receives the value
+func InOutParam(dest interface{}) Parameter {
+	return Parameter{Value: dest, Mode: ParamInOut}
+}
+
+// Named returns the parameter passed by the named notation
+func (p Parameter) Named(name string) Parameter {
+	p.Name = name
+	return p
+}
+
+// WithSize returns the parameter with the max size of the OUT value, see Parameter.Size
+func (p Parameter) WithSize(size int) Parameter {
+	p.Size = size
+	return p
+}
+
+// bindVar returns the bind value of the parameter
+func (p Parameter) bindVar() (interface{}, error) {
+	if p.Mode == ParamIn {
+		switch p.Value.(type) {
+		case nil, []byte, driver.Valuer, gorm.Valuer, clause.Expression:
+			return p.Value, nil
+		}
+		if kind := reflect.ValueOf(p.Value).Kind(); kind == reflect.Slice || kind == reflect.Array {
+			// db.Exec expands the slices into lists, go-ora binds them as PL/SQL associative arrays
+			return singleVar{value: p.Value}, nil
+		}
+		return p.Value, nil
+	}
+	if rv := reflect.ValueOf(p.Value); rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return nil, fmt.Errorf("%w: %s parameter %s requires a non-nil pointer, got %T",
+			gorm.ErrInvalidValue, p.Mode, p.Name, p.Value)
+	}
+	dest, size := p.Value, p.Size
+	switch v := dest.(type) {
+	case *RefCursor:
+		// go-ora only binds its own RefCursor
+		dest = &v.RefCursor
+	case *string, *[]byte, *sql.NullString, *go_ora.NVarChar, *go_ora.NullNVarChar:
+		if size <= 0 {
+			size = DefaultOutParameterSize
+		}
+	}
+	return go_ora.Out{Dest: dest, Size: size, In: p.Mode == ParamInOut}, nil
+}
+
+// Call calls the stored procedure with the parameters, e.g.
+//
+//	var total int
+//	var message string
+//	err := oracle.Call(db, "PKG_ORDER.SUBMIT",
+//		oracle.InParam(orderID),
+//		oracle.OutParam(&total),
+//		oracle.InOutParam(&message).WithSize(200).Named("P_MESSAGE"),
+//	).Error
+//	// BEGIN PKG_ORDER.SUBMIT(:1, :2, P_MESSAGE => :3); END;
+//
+// the statement is executed by db.Exec, so it's logged and the errors are translated like other statements
+func Call(db *gorm.DB, name string, params ...Parameter) *gorm.DB {
+	sql, vars, err := buildCall(name, nil, params)
+	if err != nil {
+		return addCallError(db, err)
+	}
+	return db.Exec(sql, vars...)
+}
+
+// CallFunction calls the stored function with the parameters, dest receives the return value, e.g.
+//
+//	var name string
+//	oracle.CallFunction(db, &name, "PKG_USER.GET_NAME", oracle.InParam(userID))
+//	// BEGIN :1 := PKG_USER.GET_NAME(:2); END;
+//
+// dest could be the parameter of OutParam to specify the max size, e.g. oracle.OutParam(&name).WithSize(200)
+func CallFunction(db *gorm.DB, dest interface{}, name string, params ...Parameter) *gorm.DB {
+	result, ok := dest.(Parameter)
+	if !ok {
+		result = OutParam(dest)
+	}
+	result.Mode = ParamOut
+
+	sql, vars, err := buildCall(name, &result, params)
+	if err != nil {
+		return addCallError(db, err)
+	}
+	return db.Exec(sql, vars...)
+}
+
+// buildCall builds the anonymous PL/SQL block calling the procedure, or the function if result is not nil
+func buildCall(name string, result *Parameter, params []Parameter) (string, []interface{}, error) {
+	if name = strings.TrimSpace(name); name == "" {
+		return "", nil, fmt.Errorf("%w: the name of stored procedure is empty", gorm.ErrInvalidData)
+	}
+
+	var builder strings.Builder
+	vars := make([]interface{}, 0, len(params)+1)
+	builder.WriteString("BEGIN ")
+	if result != nil {
+		value, err := result.bindVar()
+		if err != nil {
+			return "", nil, err
+		}
+		vars = append(vars, value)
+		builder.WriteString("? := ")
+	}
+	builder.WriteString(name)
+	builder.WriteByte('(')
+
+	var named bool
+	for idx, param := range params {
+		if idx > 0 {
+			builder.WriteString(", ")
+		}
+		// 位置参数必须在命名参数之前
+		if param.Name != "" {
+			named = true
+			builder.WriteString(param.Name)
+			builder.WriteString(" => ")
+		} else if named {
+			return "", nil, fmt.Errorf("%w: positional parameter %d of %s follows named parameters",
+				gorm.ErrInvalidData, idx+1, name)
+		}
+		value, err := param.bindVar()
+		if err != nil {
+			return "", nil, err
+		}
+		vars = append(vars, value)
+		builder.WriteByte('?')
+	}
+	builder.WriteString("); END;")
+	return builder.String(), vars, nil
+}
+
+// addCallError returns a new session of db with the error, the callbacks are not executed
+func addCallError(db *gorm.DB, err error) *gorm.DB {
+	tx := db.Session(&gorm.Session{})
+	_ = tx.AddError(err)
+	return tx
+}
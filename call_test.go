@@ -0,0 +1,157 @@
+package oracle
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestGoldenCall(t *testing.T) {
+	db, recorder := openRecorder(t, Config{}, "19.0.0.0.0")
+
+	t.Run("Procedure", func(t *testing.T) {
+		recorder.Reset()
+		var (
+			total   int
+			message = "submitted"
+		)
+		if err := Call(db, "PKG_ORDER.SUBMIT",
+			InParam(1001),
+			OutParam(&total),
+			InOutParam(&message).WithSize(200),
+		).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Named", func(t *testing.T) {
+		recorder.Reset()
+		var message string
+		if err := Call(db, "PKG_ORDER.SUBMIT",
+			InParam(1001),
+			InParam("NEW").Named("P_STATUS"),
+			OutParam(&message).WithSize(200).Named("P_MESSAGE"),
+		).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Array", func(t *testing.T) {
+		recorder.Reset()
+		var cancelled int
+		if err := Call(db, "PKG_ORDER.CANCEL_ALL",
+			InParam([]int64{1001, 1002, 1003}),
+			InParam([]string{"EXPIRED"}).Named("P_REASONS"),
+			OutParam(&cancelled).Named("P_COUNT"),
+		).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Function", func(t *testing.T) {
+		recorder.Reset()
+		var name string
+		if err := CallFunction(db, OutParam(&name).WithSize(100), "PKG_USER.GET_NAME", InParam(42)).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := CallFunction(db, &name, "SYS_CONTEXT", InParam("USERENV"), InParam("SESSION_USER")).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+}
+
+func TestCallInvalidParameters(t *testing.T) {
+	db, recorder := openRecorder(t, Config{}, "19.0.0.0.0")
+
+	var total int
+	tests := []struct {
+		name    string
+		tx      *gorm.DB
+		wantErr error
+	}{
+		{name: "EmptyName", tx: Call(db, " ", InParam(1)), wantErr: gorm.ErrInvalidData},
+		{name: "PositionalAfterNamed", tx: Call(db, "PROC", InParam(1).Named("P_ID"), InParam(2)), wantErr: gorm.ErrInvalidData},
+		{name: "OutNotPointer", tx: Call(db, "PROC", OutParam(total)), wantErr: gorm.ErrInvalidValue},
+		{name: "InOutNil", tx: Call(db, "PROC", InOutParam(nil)), wantErr: gorm.ErrInvalidValue},
+		{name: "FunctionResultNotPointer", tx: CallFunction(db, total, "FUNC"), wantErr: gorm.ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tx.Error; !errors.Is(err, tt.wantErr) {
+				t.Errorf("Call() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+	if got := recorder.Statements(); len(got) != 0 {
+		t.Errorf("the invalid calls executed statements: %v", got)
+	}
+	if db.Error != nil {
+		t.Errorf("the invalid calls changed the error of db: %v", db.Error)
+	}
+}
+
+func TestCallProcedure(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+	if err = db.Exec(procCreateExamplePagingQuery).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		totalNum  uint
+		resCursor RefCursor
+	)
+	if err = Call(db, "PRO_EXAMPLE_PAGING_QUERY",
+		InParam("SELECT * FROM USER_TABLES"),
+		InParam("TABLE_NAME"),
+		InParam(1).Named("PAGE_NUM"),
+		InParam(10).Named("PAGE_SIZE"),
+		OutParam(&totalNum).Named("TOTAL_NUM"),
+		OutParam(&resCursor).Named("RES_CURSOR"),
+	).Error; err != nil {
+		t.Fatal(err)
+	}
+	defer func(cursor *RefCursor) {
+		_ = cursor.Close()
+	}(&resCursor)
+	if totalNum == 0 {
+		t.Error("the procedure returned no total")
+	}
+
+	var user string
+	if err = CallFunction(db, OutParam(&user).WithSize(128), "SYS_CONTEXT", InParam("USERENV"), InParam("SESSION_USER")).Error; err != nil {
+		t.Fatal(err)
+	}
+	if user == "" {
+		t.Error("the function returned no value")
+	}
+}
+
+func TestCallOutSize(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	// the value is longer than the one passed in, the buffer is sized by DefaultOutParameterSize
+	var text string
+	if err = CallFunction(db, &text, "RPAD", InParam("x"), InParam(3000), InParam("x")).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(text) != 3000 {
+		t.Errorf("the function returned %d characters, want 3000", len(text))
+	}
+}
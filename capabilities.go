@@ -0,0 +1,173 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	go_ora "github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+)
+
+// Capabilities describes the SQL features supported by the database server,
+// it's detected from the server version and the COMPATIBLE parameter on initialize,
+// set Config.Capabilities to override the detection
+//
+//	db, err := gorm.Open(oracle.New(oracle.Config{
+//		DSN:          dsn,
+//		Capabilities: &oracle.Capabilities{IdentityColumns: true, FetchFirst: true},
+//	}), &gorm.Config{})
+type Capabilities struct {
+	// IdentityColumns supports GENERATED AS IDENTITY columns and sequence NEXTVAL column defaults, since 12c
+	IdentityColumns bool
+	// FetchFirst supports OFFSET n ROWS FETCH NEXT m ROWS ONLY, since 12c
+	FetchFirst bool
+	// LongIdentifiers supports identifiers up to 128 bytes, since 12.2
+	LongIdentifiers bool
+	// NativeJSON supports JSON data type, since 21c
+	NativeJSON bool
+	// Boolean supports BOOLEAN data type in SQL, since 23ai
+	Boolean bool
+	// Vector supports VECTOR data type and vector indexes, since 23ai,
+	// which reports 23.0.0.0.0 in V$INSTANCE whatever its release update is
+	Vector bool
+	// IfExists supports IF [NOT] EXISTS in DDL, since 23ai
+	IfExists bool
+	// MultiRowValues supports multiple rows in VALUES clause, since 23ai
+	MultiRowValues bool
+	// ExtendedStrings supports VARCHAR2 up to 32767 bytes, when MAX_STRING_SIZE is EXTENDED
+	ExtendedStrings bool
+}
+
+// NewCapabilities returns the capabilities of the server version, e.g. "19.0.0.0.0",
+// the features are limited by the COMPATIBLE parameter if it's not empty, e.g. "12.1.0"
+func NewCapabilities(version, compatible string, extendedStrings bool) *Capabilities {
+	v, _ := ParseServerVersion(version)
+	if c, ok := ParseServerVersion(compatible); ok && c.less(v) {
+		v = c
+	}
+
+	return &Capabilities{
+		IdentityColumns: v.AtLeast(12, 0),
+		FetchFirst:      v.AtLeast(12, 0),
+		LongIdentifiers: v.AtLeast(12, 2),
+		NativeJSON:      v.AtLeast(21, 0),
+		Boolean:         v.AtLeast(23, 0),
+		Vector:          v.AtLeast(23, 0),
+		IfExists:        v.AtLeast(23, 0),
+		MultiRowValues:  v.AtLeast(23, 0),
+		ExtendedStrings: extendedStrings,
+	}
+}
+
+// MaxIdentifierLength returns the maximum length of identifiers in bytes
+func (c *Capabilities) MaxIdentifierLength() int {
+	if c.LongIdentifiers {
+		return 128
+	}
+	return 30
+}
+
+// MaxStringSize returns the maximum size of VARCHAR2 in bytes
+func (c *Capabilities) MaxStringSize() int {
+	if c.ExtendedStrings {
+		return 32767
+	}
+	return 4000
+}
+
+// capabilities returns the capabilities of the dialector,
+// they're derived from DBVer once if not detected or overridden
+func (d Dialector) capabilities() *Capabilities {
+	if d.Config == nil {
+		return &Capabilities{}
+	}
+	if d.Capabilities != nil {
+		return d.Capabilities
+	}
+	if derived, ok := d.versionCapabilities.Load().(versionCapabilities); ok && derived.version == d.DBVer {
+		return derived.Capabilities
+	}
+	derived := versionCapabilities{version: d.DBVer, Capabilities: NewCapabilities(d.DBVer, "", false)}
+	d.versionCapabilities.Store(derived)
+	return derived.Capabilities
+}
+
+// versionCapabilities are the capabilities derived from the version
+type versionCapabilities struct {
+	version string
+	*Capabilities
+}
+
+// getCapabilities returns the capabilities of the dialector of db
+func getCapabilities(db *gorm.DB) *Capabilities {
+	if d, ok := ptrDereference(db.Dialector).(Dialector); ok {
+		return d.capabilities()
+	}
+	return &Capabilities{}
+}
+
+// useNativeBoolean reports whether bool fields are mapped to the BOOLEAN type, see Config.NativeBoolean
+func (d Dialector) useNativeBoolean() bool {
+	return d.Config != nil && d.NativeBoolean && d.capabilities().Boolean
+}
+
+// getNativeBoolean reports whether the dialector of db maps bool fields to the BOOLEAN type
+func getNativeBoolean(db *gorm.DB) bool {
+	if d, ok := ptrDereference(db.Dialector).(Dialector); ok {
+		return d.useNativeBoolean()
+	}
+	return false
+}
+
+// detectCapabilities detects the capabilities with the server version and initialization parameters,
+// COMPATIBLE is read from DBMS_UTILITY.DB_VERSION when the user has no privilege to query V$PARAMETER,
+// and ExtendedStrings is false then
+//
+//goland:noinspection SqlNoDataSourceInspection
+func (d Dialector) detectCapabilities(db *gorm.DB) *Capabilities {
+	ctx := context.Background()
+	compatible, maxStringSize, err := queryParameters(ctx, db.ConnPool)
+	if err != nil {
+		db.Logger.Warn(ctx, "failed to query V$PARAMETER: %v, Capabilities.ExtendedStrings is false, "+
+			"set Config.Capabilities to specify it", err)
+	}
+	if compatible == "" {
+		var version string
+		if _, err = db.ConnPool.ExecContext(ctx, "BEGIN DBMS_UTILITY.DB_VERSION(:1, :2); END;",
+			go_ora.Out{Dest: &version, Size: 100}, go_ora.Out{Dest: &compatible, Size: 100}); err != nil {
+			db.Logger.Warn(ctx, "failed to detect the COMPATIBLE parameter: %v, "+
+				"the capabilities are derived from the server version", err)
+		}
+	}
+	return NewCapabilities(d.DBVer, compatible, strings.EqualFold(maxStringSize, "EXTENDED"))
+}
+
+// queryParameters queries the COMPATIBLE and MAX_STRING_SIZE parameters from V$PARAMETER
+//
+//goland:noinspection SqlNoDataSourceInspection
+func queryParameters(ctx context.Context, pool gorm.ConnPool) (compatible, maxStringSize string, err error) {
+	rows, err := pool.QueryContext(ctx, "SELECT NAME, VALUE FROM V$PARAMETER WHERE NAME IN ('compatible', 'max_string_size')")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if closeErr := rows.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	for rows.Next() {
+		var name, value sql.NullString
+		if err = rows.Scan(&name, &value); err != nil {
+			return
+		}
+		switch strings.ToLower(name.String) {
+		case "compatible":
+			compatible = value.String
+		case "max_string_size":
+			maxStringSize = value.String
+		}
+	}
+	err = rows.Err()
+	return
+}
@@ -0,0 +1,85 @@
+package oracle
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sijms/go-ora/v2/network"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+func TestNewCapabilities(t *testing.T) {
+	tests := []struct {
+		version, compatible string
+		extendedStrings     bool
+		want                Capabilities
+	}{
+		{version: "", want: Capabilities{}},
+		{version: "11.2.0.4.0", want: Capabilities{}},
+		{version: "12.1.0.2.0", want: Capabilities{IdentityColumns: true, FetchFirst: true}},
+		{version: "12.2.0.1.0", want: Capabilities{IdentityColumns: true, FetchFirst: true, LongIdentifiers: true}},
+		{version: "19.0.0.0.0", extendedStrings: true, want: Capabilities{
+			IdentityColumns: true, FetchFirst: true, LongIdentifiers: true, ExtendedStrings: true,
+		}},
+		{version: "19.0.0.0.0", compatible: "12.1.0", want: Capabilities{IdentityColumns: true, FetchFirst: true}},
+		{version: "21.0.0.0.0", want: Capabilities{
+			IdentityColumns: true, FetchFirst: true, LongIdentifiers: true, NativeJSON: true,
+		}},
+		{version: "23.0.0.0.0", want: Capabilities{
+			IdentityColumns: true, FetchFirst: true, LongIdentifiers: true, NativeJSON: true,
+			Boolean: true, Vector: true, IfExists: true, MultiRowValues: true,
+		}},
+		{version: "23.4.0.24.05", want: Capabilities{
+			IdentityColumns: true, FetchFirst: true, LongIdentifiers: true, NativeJSON: true,
+			Boolean: true, Vector: true, IfExists: true, MultiRowValues: true,
+		}},
+		{version: "23.4.0.24.05", compatible: "23.4.0", want: Capabilities{
+			IdentityColumns: true, FetchFirst: true, LongIdentifiers: true, NativeJSON: true,
+			Boolean: true, Vector: true, IfExists: true, MultiRowValues: true,
+		}},
+		{version: "23.4.0.24.05", compatible: "19.0.0", want: Capabilities{
+			IdentityColumns: true, FetchFirst: true, LongIdentifiers: true,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+"/"+tt.compatible, func(t *testing.T) {
+			got := NewCapabilities(tt.version, tt.compatible, tt.extendedStrings)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("NewCapabilities() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectCapabilities(t *testing.T) {
+	recorder := oracletest.NewRecorder()
+	recorder.Version = "19.0.0.0.0"
+	// V$PARAMETER isn't granted to the application users usually
+	recorder.Script(`FROM V\$PARAMETER`, oracletest.Result{
+		Err: &network.OracleError{ErrCode: 942, ErrMsg: "ORA-00942: table or view does not exist"},
+	})
+	recorder.Script(`DBMS_UTILITY\.DB_VERSION`, oracletest.Result{Out: []interface{}{"19.0.0.0.0", "12.1.0"}})
+	db, err := gorm.Open(New(Config{Conn: recorder.DB()}), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Capabilities{IdentityColumns: true, FetchFirst: true}
+	if got := getCapabilities(db); !reflect.DeepEqual(*got, want) {
+		t.Errorf("detected capabilities = %+v, want %+v", *got, want)
+	}
+}
+
+func TestDialectorCapabilities(t *testing.T) {
+	d := Dialector{Config: &Config{DBVer: "19.0.0.0.0"}}
+	if got := d.capabilities(); got != d.capabilities() || !got.FetchFirst {
+		t.Errorf("capabilities() of DBVer = %+v, want the same FetchFirst capabilities every time", got)
+	}
+	d.DBVer = "11.2.0.4.0"
+	if got := d.capabilities(); got.FetchFirst {
+		t.Errorf("capabilities() of changed DBVer = %+v, want no FetchFirst", got)
+	}
+}
@@ -0,0 +1,115 @@
+package oracle
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// ClientInfo is the information of the client shown in V$SESSION and AWR, see WithClientInfo
+type ClientInfo struct {
+	// Module and Action are set by DBMS_APPLICATION_INFO.SET_MODULE
+	Module string
+	Action string
+	// ClientID is set by DBMS_SESSION.SET_IDENTIFIER, it's the CLIENT_IDENTIFIER of the session
+	ClientID string
+}
+
+type clientInfoContextKey struct{}
+
+const clientInfoStateKey = "oracle:client_info_state"
+
+// WithClientInfo returns the context with the client information, the statements executed with it
+// set the information on their connection before, and clear it after, e.g.
+//
+//	ctx = oracle.WithClientInfo(ctx, "order-service", "SubmitOrder", userID)
+//	db.WithContext(ctx).Create(&order)
+//
+// Each statement costs two more round trips, the statements in a transaction are executed by the connection
+// of the transaction, the others by a dedicated connection of the pool. The rows of Row and Rows are read
+// after the statement returns, so the information isn't set for them.
+func WithClientInfo(ctx context.Context, module, action, clientID string) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey{}, ClientInfo{Module: module, Action: action, ClientID: clientID})
+}
+
+// ClientInfoFromContext returns the client information of the context set by WithClientInfo
+func ClientInfoFromContext(ctx context.Context) (info ClientInfo, ok bool) {
+	if ctx != nil {
+		info, ok = ctx.Value(clientInfoContextKey{}).(ClientInfo)
+	}
+	return
+}
+
+// registerClientInfoCallbacks registers the callbacks of the client information around the statements of processors,
+// the rows of Row and Rows are read after the callbacks, so the client information isn't set for them
+func registerClientInfoCallbacks(db *gorm.DB) (err error) {
+	callback := db.Callback()
+	if err = callback.Create().Before("gorm:create").Register("oracle:before_client_info", beforeClientInfo); err != nil {
+		return
+	}
+	if err = callback.Create().After("gorm:create").Register("oracle:after_client_info", afterClientInfo); err != nil {
+		return
+	}
+	if err = callback.Query().Before("gorm:query").Register("oracle:before_client_info", beforeClientInfo); err != nil {
+		return
+	}
+	// the associations are preloaded on the same connection before the client information is cleared
+	if err = callback.Query().After("gorm:after_query").Register("oracle:after_client_info", afterClientInfo); err != nil {
+		return
+	}
+	if err = callback.Update().Before("gorm:update").Register("oracle:before_client_info", beforeClientInfo); err != nil {
+		return
+	}
+	if err = callback.Update().After("gorm:update").Register("oracle:after_client_info", afterClientInfo); err != nil {
+		return
+	}
+	if err = callback.Delete().Before("gorm:delete").Register("oracle:before_client_info", beforeClientInfo); err != nil {
+		return
+	}
+	if err = callback.Delete().After("gorm:delete").Register("oracle:after_client_info", afterClientInfo); err != nil {
+		return
+	}
+	// the output of DBMS_OUTPUT is captured within the client information
+	if err = callback.Raw().Before("oracle:before_dbms_output").Register("oracle:before_client_info", beforeClientInfo); err != nil {
+		return
+	}
+	return callback.Raw().After("oracle:after_dbms_output").Register("oracle:after_client_info", afterClientInfo)
+}
+
+// beforeClientInfo sets the client information of the context on the connection of the statement
+func beforeClientInfo(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || db.Statement.ConnPool == nil {
+		return
+	}
+	info, ok := ClientInfoFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+	pinned, err := pinConn(db)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	db.InstanceSet(clientInfoStateKey, pinned)
+	if _, err = db.Statement.ConnPool.ExecContext(db.Statement.Context,
+		"BEGIN DBMS_APPLICATION_INFO.SET_MODULE(:1, :2); DBMS_SESSION.SET_IDENTIFIER(:3); END;",
+		info.Module, info.Action, info.ClientID); err != nil {
+		_ = db.AddError(err)
+	}
+}
+
+// afterClientInfo clears the client information on the connection even if the statement fails or its context
+// is canceled, so the connection returned to the pool doesn't carry it, it's discarded if it fails to clear
+func afterClientInfo(db *gorm.DB) {
+	v, ok := db.InstanceGet(clientInfoStateKey)
+	if !ok {
+		return
+	}
+	pinned := v.(*pinnedConn)
+	defer pinned.release(db)
+
+	if err := pinned.reset(db,
+		"BEGIN DBMS_APPLICATION_INFO.SET_MODULE(NULL, NULL); DBMS_SESSION.CLEAR_IDENTIFIER; END;"); err != nil {
+		db.Logger.Warn(db.Statement.Context, "failed to clear the client information: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+type testClientInfoOrder struct {
+	ID     uint64 `gorm:"primaryKey;autoIncrement:false"`
+	Status string `gorm:"size:20"`
+}
+
+type testClientInfoCustomer struct {
+	ID     uint64               `gorm:"primaryKey;autoIncrement:false"`
+	Orders []testClientInfoLine `gorm:"foreignKey:CustomerID"`
+}
+
+type testClientInfoLine struct {
+	ID         uint64 `gorm:"primaryKey;autoIncrement:false"`
+	CustomerID uint64
+}
+
+func TestGoldenClientInfo(t *testing.T) {
+	t.Run("Statements", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+		tx := db.WithContext(WithClientInfo(context.Background(), "order-service", "SubmitOrder", "user-42"))
+
+		order := testClientInfoOrder{ID: 1, Status: "NEW"}
+		if err := tx.Create(&order).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Model(&order).Update("Status", "SUBMITTED").Error; err != nil {
+			t.Fatal(err)
+		}
+		var orders []testClientInfoOrder
+		if err := tx.Find(&orders).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Delete(&order).Error; err != nil {
+			t.Fatal(err)
+		}
+		// the statements without the client information are executed as they are
+		if err := db.Exec("SELECT 1 FROM DUAL").Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Preload", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+		recorder.Script(`FROM "test_client_info_customers"`, oracletest.Result{
+			Columns: []string{"id"}, Rows: [][]driver.Value{{int64(1)}},
+		})
+		ctx := WithClientInfo(context.Background(), "order-service", "ListCustomers", "")
+		var customers []testClientInfoCustomer
+		// the client information is cleared after the associations are preloaded
+		if err := db.WithContext(ctx).Preload("Orders").Find(&customers).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{DBMSOutput: true}, "19.0.0.0.0")
+		ctx := WithClientInfo(context.Background(), "order-service", "CancelOrder", "")
+		if err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.Exec("BEGIN PKG_ORDER.CANCEL(?); END;", 1).Error
+		}); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+}
+
+func TestClientInfoCanceled(t *testing.T) {
+	db, recorder := openRecorder(t, Config{}, "19.0.0.0.0")
+	ctx, cancel := context.WithCancel(WithClientInfo(context.Background(), "order-service", "Timeout", "user-42"))
+	defer cancel()
+	// the context is canceled while the statement runs, like a request timing out
+	if err := db.Callback().Raw().After("gorm:raw").Before("oracle:after_client_info").
+		Register("test:cancel", func(*gorm.DB) { cancel() }); err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = db.WithContext(ctx).Exec("BEGIN PKG_ORDER.SUBMIT(?); END;", 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	statements := recorder.Statements()
+	if last := statements[len(statements)-1].SQL; last != "BEGIN DBMS_APPLICATION_INFO.SET_MODULE(NULL, NULL); DBMS_SESSION.CLEAR_IDENTIFIER; END;" {
+		t.Errorf("the client information isn't cleared after the context is canceled: %v", statements)
+	}
+	if idle := sqlDB.Stats().Idle; idle != 1 {
+		t.Errorf("the cleared connection isn't returned to the pool, idle = %d", idle)
+	}
+
+	// the connection which fails to clear the client information is discarded
+	recorder.Script(`CLEAR_IDENTIFIER`, oracletest.Result{Err: errors.New("ORA-01013: user requested cancel of current operation")})
+	ctx = WithClientInfo(context.Background(), "order-service", "Broken", "")
+	if err = db.WithContext(ctx).Exec("BEGIN PKG_ORDER.SUBMIT(?); END;", 2).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stats := sqlDB.Stats(); stats.Idle != 0 || stats.OpenConnections != 0 {
+		t.Errorf("the connection carrying the client information is pooled, stats = %+v", stats)
+	}
+}
+
+func TestClientInfoFromContext(t *testing.T) {
+	if _, ok := ClientInfoFromContext(context.Background()); ok {
+		t.Error("ClientInfoFromContext() of the background context is ok")
+	}
+	ctx := WithClientInfo(context.Background(), "module", "action", "client")
+	if info, ok := ClientInfoFromContext(ctx); !ok || info != (ClientInfo{Module: "module", Action: "action", ClientID: "client"}) {
+		t.Errorf("ClientInfoFromContext() = %+v, %v", info, ok)
+	}
+}
+
+func TestClientInfo(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	ctx := WithClientInfo(context.Background(), "gorm-oracle", "TestClientInfo", "tester")
+	var info ClientInfo
+	if err = db.WithContext(ctx).Raw(`SELECT SYS_CONTEXT('USERENV', 'MODULE') AS "Module", ` +
+		`SYS_CONTEXT('USERENV', 'ACTION') AS "Action", ` +
+		`SYS_CONTEXT('USERENV', 'CLIENT_IDENTIFIER') AS "ClientID" FROM DUAL`).Scan(&info).Error; err != nil {
+		t.Fatal(err)
+	}
+	if want := (ClientInfo{Module: "gorm-oracle", Action: "TestClientInfo", ClientID: "tester"}); info != want {
+		t.Errorf("got %+v, want %+v", info, want)
+	}
+}
@@ -1,12 +1,18 @@
 package oracle
 
 import (
+	"database/sql/driver"
+	"fmt"
 	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/sijms/go-ora/v2"
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils"
 )
 
 func Create(db *gorm.DB) {
@@ -26,16 +32,24 @@ func Create(db *gorm.DB) {
 		var (
 			createValues            = callbacks.ConvertToCreateValues(stmt)
 			onConflict, hasConflict = stmt.Clauses["ON CONFLICT"].Expression.(clause.OnConflict)
+			lenDefaultValue         int
+			bulkReturning           bool
+			nativeBoolean           = getNativeBoolean(db)
 		)
+		convertObjectValues(stmtSchema, createValues)
 
+		var mergeFields []*schema.Field
 		if hasConflict {
-			if stmtSchema != nil && len(stmtSchema.PrimaryFields) > 0 {
+			if stmtSchema != nil {
+				mergeFields = conflictFields(stmtSchema, onConflict)
+			}
+			if len(mergeFields) > 0 {
 				columnsMap := map[string]bool{}
 				for _, column := range createValues.Columns {
 					columnsMap[column.Name] = true
 				}
 
-				for _, field := range stmtSchema.PrimaryFields {
+				for _, field := range mergeFields {
 					if _, ok := columnsMap[field.DBName]; !ok {
 						hasConflict = false
 					}
@@ -47,31 +61,45 @@ func Create(db *gorm.DB) {
 
 		if hasConflict {
 			MergeCreate(db, onConflict, createValues)
+		} else if bulkVars, ok := bulkCreateVars(db, createValues); ok {
+			bulkReturning = true
+			BulkCreate(db, createValues, bulkVars)
 		} else {
 			stmt.AddClauseIfNotExists(clause.Insert{})
 			stmt.AddClause(clause.Values{Columns: createValues.Columns, Values: [][]interface{}{createValues.Values[0]}})
 
 			stmt.Build("INSERT", "VALUES")
-			_ = outputInserted(db)
+			lenDefaultValue = outputInserted(db)
 		}
 
 		if !db.DryRun && db.Error == nil {
 			if hasConflict {
 				for i, val := range stmt.Vars {
 					// HACK: replace values one by one, assuming its value layout will be the same all the time, i.e. aligned
-					stmt.Vars[i] = convertValue(val)
+					stmt.Vars[i] = convertValue(val, nativeBoolean)
 				}
 
 				result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 				if db.AddError(err) == nil {
 					db.RowsAffected, _ = result.RowsAffected()
-					// TODO: get merged returning
+					getMergedValues(db, mergeFields)
+				}
+			} else if bulkReturning {
+				if _, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...); db.AddError(err) == nil {
+					getBulkDefaultValues(db)
+				}
+			} else if lenDefaultValue == 0 && len(createValues.Values) > 1 && len(stmt.Vars) == len(createValues.Columns) &&
+				!hasNonArrayValue(createValues.Values[0]) {
+				// array DML: every bind is a slice of column values, the whole batch is sent in one round trip
+				result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), arrayBindVars(createValues.Values, nativeBoolean)...)
+				if db.AddError(err) == nil {
+					db.RowsAffected, _ = result.RowsAffected()
 				}
 			} else {
 				for idx, values := range createValues.Values {
 					for i, val := range values {
 						// HACK: replace values one by one, assuming its value layout will be the same all the time, i.e. aligned
-						stmt.Vars[i] = convertValue(val)
+						stmt.Vars[i] = convertValue(val, nativeBoolean)
 					}
 
 					result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
@@ -121,6 +149,96 @@ func outputInserted(db *gorm.DB) (lenDefaultValue int) {
 	return
 }
 
+// BulkCreate build a PL/SQL block that inserts all the values with one FORALL statement,
+// and collects the values generated by the database for every row into array out-binds
+//
+//	DECLARE
+//		TYPE T0 IS TABLE OF "table"."column"%TYPE INDEX BY PLS_INTEGER; V0 T0 := :1;
+//		TYPE R0 IS TABLE OF "table"."id"%TYPE INDEX BY PLS_INTEGER; O0 R0;
+//	BEGIN
+//		FORALL I IN 1 .. V0.COUNT INSERT INTO "table" ("column") VALUES (V0(I)) RETURNING "id" BULK COLLECT INTO O0;
+//		:2 := O0;
+//	END;
+func BulkCreate(db *gorm.DB, values clause.Values, vars []interface{}) {
+	stmt := db.Statement
+	defaultFields := stmt.Schema.FieldsWithDefaultDBValue
+
+	writeType := func(typeName string, column string) {
+		_, _ = stmt.WriteString("TYPE ")
+		_, _ = stmt.WriteString(typeName)
+		_, _ = stmt.WriteString(" IS TABLE OF ")
+		stmt.WriteQuoted(clause.Column{Table: stmt.Table, Name: column})
+		_, _ = stmt.WriteString("%TYPE INDEX BY PLS_INTEGER; ")
+	}
+
+	_, _ = stmt.WriteString("DECLARE ")
+	for idx, column := range values.Columns {
+		writeType("T"+strconv.Itoa(idx), column.Name)
+		_, _ = fmt.Fprintf(&stmt.SQL, "V%d T%d := ", idx, idx)
+		addArrayVar(stmt, vars[idx])
+		_, _ = stmt.WriteString("; ")
+	}
+	for idx, field := range defaultFields {
+		writeType("R"+strconv.Itoa(idx), field.DBName)
+		_, _ = fmt.Fprintf(&stmt.SQL, "O%d R%d; ", idx, idx)
+	}
+
+	_, _ = stmt.WriteString("BEGIN FORALL I IN 1 .. V0.COUNT INSERT INTO ")
+	stmt.WriteQuoted(clause.Table{Name: stmt.Table})
+	_, _ = stmt.WriteString(" (")
+	for idx, column := range values.Columns {
+		if idx > 0 {
+			_ = stmt.WriteByte(',')
+		}
+		stmt.WriteQuoted(column.Name)
+	}
+	_, _ = stmt.WriteString(") VALUES (")
+	for idx := range values.Columns {
+		if idx > 0 {
+			_ = stmt.WriteByte(',')
+		}
+		_, _ = fmt.Fprintf(&stmt.SQL, "V%d(I)", idx)
+	}
+	_, _ = stmt.WriteString(") RETURNING ")
+	for idx, field := range defaultFields {
+		if idx > 0 {
+			_ = stmt.WriteByte(',')
+		}
+		stmt.WriteQuoted(field.DBName)
+	}
+	_, _ = stmt.WriteString(" BULK COLLECT INTO ")
+	for idx := range defaultFields {
+		if idx > 0 {
+			_ = stmt.WriteByte(',')
+		}
+		_, _ = fmt.Fprintf(&stmt.SQL, "O%d", idx)
+	}
+	_, _ = stmt.WriteString("; ")
+
+	for idx, field := range defaultFields {
+		outVar := go_ora.Out{
+			Dest: reflect.New(reflect.SliceOf(field.FieldType)).Interface(),
+			Size: len(values.Values),
+		}
+		stmt.AddVar(stmt, outVar)
+		_, _ = fmt.Fprintf(&stmt.SQL, " := O%d; ", idx)
+	}
+	_, _ = stmt.WriteString("END;")
+}
+
+// addArrayVar add a slice to the statement vars as a single bind,
+// Statement.AddVar would expand it into a list of binds
+func addArrayVar(stmt *gorm.Statement, v interface{}) {
+	stmt.Vars = append(stmt.Vars, v)
+	stmt.DB.Dialector.BindVarTo(stmt, stmt, v)
+}
+
+// MergeCreate build a MERGE statement for the upsert of values
+//
+// The rows are matched on OnConflict.Columns, or on the primary keys if no columns are specified,
+// OnConflict.TargetWhere is added to the ON condition and OnConflict.Where filters the rows to be updated.
+// As both the table and the "excluded" source rows have the same columns,
+// the columns referenced in these conditions should be qualified with the table name or "excluded".
 func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values) {
 	dummyTable := getDummyTable(db)
 
@@ -128,42 +246,82 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 	db.Statement.WriteQuoted(db.Statement.Table)
 	_, _ = db.Statement.WriteString(" USING (")
 
-	for idx, value := range values.Values {
-		if idx > 0 {
-			_, _ = db.Statement.WriteString(" UNION ALL ")
+	if getCapabilities(db).MultiRowValues {
+		// Oracle 23ai 支持 VALUES 表值构造器
+		_, _ = db.Statement.WriteString("VALUES ")
+		for idx, value := range values.Values {
+			if idx > 0 {
+				_ = db.Statement.WriteByte(',')
+			}
+			_ = db.Statement.WriteByte('(')
+			db.Statement.AddVar(db.Statement, value...)
+			_ = db.Statement.WriteByte(')')
 		}
-
-		_, _ = db.Statement.WriteString("SELECT ")
-		for i, v := range value {
-			if i > 0 {
+		_, _ = db.Statement.WriteString(`) `)
+		db.Statement.WriteQuoted("excluded")
+		_, _ = db.Statement.WriteString(" (")
+		for idx, column := range values.Columns {
+			if idx > 0 {
 				_ = db.Statement.WriteByte(',')
 			}
-			column := values.Columns[i]
-			db.Statement.AddVar(db.Statement, v)
-			_, _ = db.Statement.WriteString(" AS ")
 			db.Statement.WriteQuoted(column.Name)
 		}
-		_, _ = db.Statement.WriteString(" FROM ")
-		_, _ = db.Statement.WriteString(dummyTable)
-	}
+		_ = db.Statement.WriteByte(')')
+	} else {
+		for idx, value := range values.Values {
+			if idx > 0 {
+				_, _ = db.Statement.WriteString(" UNION ALL ")
+			}
+
+			_, _ = db.Statement.WriteString("SELECT ")
+			for i, v := range value {
+				if i > 0 {
+					_ = db.Statement.WriteByte(',')
+				}
+				column := values.Columns[i]
+				db.Statement.AddVar(db.Statement, v)
+				_, _ = db.Statement.WriteString(" AS ")
+				db.Statement.WriteQuoted(column.Name)
+			}
+			_, _ = db.Statement.WriteString(" FROM ")
+			_, _ = db.Statement.WriteString(dummyTable)
+		}
 
-	_, _ = db.Statement.WriteString(`) `)
-	db.Statement.WriteQuoted("excluded")
+		_, _ = db.Statement.WriteString(`) `)
+		db.Statement.WriteQuoted("excluded")
+	}
 	_, _ = db.Statement.WriteString(" ON (")
 
-	var where clause.Where
-	for _, field := range db.Statement.Schema.PrimaryFields {
+	var (
+		where       clause.Where
+		mergeFields = conflictFields(db.Statement.Schema, onConflict)
+		mergeNames  = make([]string, 0, len(mergeFields))
+	)
+	for _, field := range mergeFields {
+		mergeNames = append(mergeNames, field.DBName)
 		where.Exprs = append(where.Exprs, clause.Eq{
 			Column: clause.Column{Table: db.Statement.Table, Name: field.DBName},
 			Value:  clause.Column{Table: "excluded", Name: field.DBName},
 		})
 	}
+	where.Exprs = append(where.Exprs, onConflict.TargetWhere.Exprs...)
 	where.Build(db.Statement)
 	_ = db.Statement.WriteByte(')')
 
-	if len(onConflict.DoUpdates) > 0 {
+	// the columns referenced in the ON clause cannot be updated (ORA-38104)
+	doUpdates := make(clause.Set, 0, len(onConflict.DoUpdates))
+	for _, assignment := range onConflict.DoUpdates {
+		if !utils.Contains(mergeNames, assignment.Column.Name) {
+			doUpdates = append(doUpdates, assignment)
+		}
+	}
+	if len(doUpdates) > 0 && !onConflict.DoNothing {
 		_, _ = db.Statement.WriteString(" WHEN MATCHED THEN UPDATE SET ")
-		onConflict.DoUpdates.Build(db.Statement)
+		doUpdates.Build(db.Statement)
+		if len(onConflict.Where.Exprs) > 0 {
+			_, _ = db.Statement.WriteString(" WHERE ")
+			onConflict.Where.Build(db.Statement)
+		}
 	}
 
 	_, _ = db.Statement.WriteString(" WHEN NOT MATCHED THEN INSERT (")
@@ -197,25 +355,188 @@ func MergeCreate(db *gorm.DB, onConflict clause.OnConflict, values clause.Values
 	_, _ = db.Statement.WriteString(")")
 }
 
-func convertValue(val interface{}) interface{} {
+// conflictFields returns the fields that MergeCreate matches the existing rows on,
+// the OnConflict columns take precedence over the primary keys
+func conflictFields(stmtSchema *schema.Schema, onConflict clause.OnConflict) (fields []*schema.Field) {
+	if len(onConflict.Columns) == 0 {
+		return stmtSchema.PrimaryFields
+	}
+	for _, column := range onConflict.Columns {
+		field := stmtSchema.LookUpField(column.Name)
+		if field == nil {
+			return nil
+		}
+		fields = append(fields, field)
+	}
+	return
+}
+
+func convertValue(val interface{}, nativeBoolean bool) interface{} {
+	if obj, ok := val.(*go_ora.Object); ok {
+		return obj
+	}
 	val = ptrDereference(val)
 	switch v := val.(type) {
 	case bool:
-		if v {
-			val = 1
-		} else {
-			val = 0
-		}
+		val = convertBool(v, nativeBoolean)
 	case string:
 		if len(v) > 2000 {
 			val = go_ora.Clob{String: v, Valid: true}
 		}
+	case vectorValuer:
+		if vector, err := v.oracleVector(); err == nil {
+			val = vector
+		}
 	default:
 		val = convertCustomType(val)
 	}
 	return val
 }
 
+// convertBool converts bool to the bind of BOOLEAN column on Oracle 23ai, or to 1 and 0 of NUMBER(1) column
+func convertBool(v bool, nativeBoolean bool) interface{} {
+	if nativeBoolean {
+		return go_ora.PLBool(v)
+	} else if v {
+		return 1
+	}
+	return 0
+}
+
+// arrayBindVars transposes the rows of values into one slice per column,
+// which go-ora binds as arrays when executing a DML statement
+func arrayBindVars(values [][]interface{}, nativeBoolean bool) (vars []interface{}) {
+	if len(values) == 0 {
+		return
+	}
+	vars = make([]interface{}, len(values[0]))
+	for i := range vars {
+		column := make([]interface{}, len(values))
+		hasClob := false
+		for idx, value := range values {
+			column[idx] = convertValue(value[i], nativeBoolean)
+			if _, ok := column[idx].(go_ora.Clob); ok {
+				hasClob = true
+			}
+		}
+		// go-ora binds all the elements of an array by the type of the last non-null one,
+		// so the short strings are bound as CLOB too if any string of the column is
+		if hasClob {
+			for idx, val := range column {
+				if v, ok := val.(string); ok {
+					column[idx] = go_ora.Clob{String: v, Valid: true}
+				}
+			}
+		}
+		vars[i] = column
+	}
+	return
+}
+
+// hasNonArrayValue reports whether the values contain VECTOR values or objects and collections,
+// which go-ora cannot bind as arrays
+func hasNonArrayValue(values []interface{}) bool {
+	for _, val := range values {
+		switch val.(type) {
+		case vectorValuer, *go_ora.Object:
+			return true
+		}
+	}
+	return false
+}
+
+// bulkCreateVars returns one typed slice per column when the values should be inserted by BulkCreate,
+// that is, there is more than one row and the database generates values which must be returned
+func bulkCreateVars(db *gorm.DB, values clause.Values) (vars []interface{}, ok bool) {
+	stmtSchema := db.Statement.Schema
+	nativeBoolean := getNativeBoolean(db)
+	if stmtSchema == nil || len(stmtSchema.FieldsWithDefaultDBValue) == 0 ||
+		len(values.Values) < 2 || len(values.Columns) == 0 {
+		return
+	}
+	if reflect.Indirect(db.Statement.ReflectValue).Kind() != reflect.Slice &&
+		reflect.Indirect(db.Statement.ReflectValue).Kind() != reflect.Array {
+		return
+	}
+
+	vars = make([]interface{}, len(values.Columns))
+	for i, c := range values.Columns {
+		column := make([]interface{}, len(values.Values))
+		for idx, value := range values.Values {
+			column[idx] = value[i]
+		}
+		nullType := reflect.TypeOf("")
+		if field := stmtSchema.LookUpField(c.Name); field != nil {
+			if t := field.IndirectFieldType; t.Kind() == reflect.Bool {
+				nullType = reflect.TypeOf(convertBool(false, nativeBoolean))
+			} else if t.Kind() <= reflect.Float64 || t == reflect.TypeOf(time.Time{}) {
+				nullType = t
+			}
+		}
+		if vars[i], ok = typedArray(column, nullType, nativeBoolean); !ok {
+			return nil, false
+		}
+	}
+	return
+}
+
+// typedArray converts the values of a column into a slice of pointers to a single type,
+// go-ora binds such a slice as a PL/SQL index-by table and nil pointers as NULL,
+// nullType is the element type used when every value is NULL
+func typedArray(column []interface{}, nullType reflect.Type, nativeBoolean bool) (array interface{}, ok bool) {
+	var elemType reflect.Type
+	converted := make([]interface{}, len(column))
+	for idx, val := range column {
+		switch val.(type) {
+		case clause.Expression, gorm.Valuer, *gorm.DB, *go_ora.Object:
+			return
+		}
+
+		val = ptrDereference(val)
+		switch v := val.(type) {
+		case bool:
+			val = convertBool(v, nativeBoolean)
+		default:
+			val = convertCustomType(val)
+		}
+		if valuer, isValuer := val.(driver.Valuer); isValuer {
+			var err error
+			if val, err = valuer.Value(); err != nil {
+				return
+			}
+		}
+
+		if rv := reflect.ValueOf(val); !rv.IsValid() || rv.Kind() == reflect.Ptr && rv.IsNil() {
+			continue
+		} else if rv.Kind() == reflect.Ptr {
+			val = rv.Elem().Interface()
+		}
+		if t := reflect.TypeOf(val); elemType == nil {
+			elemType = t
+		} else if t != elemType {
+			return
+		}
+		converted[idx] = val
+	}
+	if elemType == nil {
+		elemType = nullType
+	}
+	if k := elemType.Kind(); k == reflect.Slice && elemType.Elem().Kind() != reflect.Uint8 ||
+		k == reflect.Array || k == reflect.Map || k == reflect.Struct && elemType != reflect.TypeOf(time.Time{}) {
+		return
+	}
+
+	rv := reflect.MakeSlice(reflect.SliceOf(reflect.PointerTo(elemType)), len(converted), len(converted))
+	for idx, val := range converted {
+		if val != nil {
+			elem := reflect.New(elemType)
+			elem.Elem().Set(reflect.ValueOf(val))
+			rv.Index(idx).Set(elem)
+		}
+	}
+	return rv.Interface(), true
+}
+
 func getDummyTable(db *gorm.DB) (dummyTable string) {
 	switch d := ptrDereference(db.Dialector).(type) {
 	case Dialector:
@@ -239,32 +560,105 @@ func getDefaultValues(db *gorm.DB, idx int) {
 	if insertTo.Kind() == reflect.Pointer {
 		insertTo = insertTo.Elem()
 	}
+	if insertTo.Kind() != reflect.Struct {
+		return
+	}
 
+	defaultFields := db.Statement.Schema.FieldsWithDefaultDBValue
+	outIdx := 0
 	for _, val := range db.Statement.Vars {
-		switch v := val.(type) {
-		case go_ora.Out:
-			switch insertTo.Kind() {
-			case reflect.Slice, reflect.Array:
-				for i := insertTo.Len() - 1; i >= 0; i-- {
-					rv := insertTo.Index(i)
-					switch reflect.Indirect(rv).Kind() {
-					case reflect.Struct:
-						setStructFieldValue(db, rv, v)
-					default:
-					}
+		if v, ok := val.(go_ora.Out); ok && outIdx < len(defaultFields) {
+			setStructFieldValue(db, defaultFields[outIdx], insertTo, v.Dest)
+			outIdx++
+		}
+	}
+}
+
+// getBulkDefaultValues set the array out-binds of BulkCreate to every element of the inserted slice
+func getBulkDefaultValues(db *gorm.DB) {
+	insertTo := reflect.Indirect(db.Statement.ReflectValue)
+	defaultFields := db.Statement.Schema.FieldsWithDefaultDBValue
+	outIdx := 0
+	for _, val := range db.Statement.Vars {
+		v, ok := val.(go_ora.Out)
+		if !ok || outIdx >= len(defaultFields) {
+			continue
+		}
+		field := defaultFields[outIdx]
+		dest := reflect.Indirect(reflect.ValueOf(v.Dest))
+		if outIdx == 0 {
+			db.RowsAffected = int64(dest.Len())
+		}
+		for i := 0; i < dest.Len() && i < insertTo.Len(); i++ {
+			rv := insertTo.Index(i)
+			if reflect.Indirect(rv).Kind() == reflect.Struct {
+				setStructFieldValue(db, field, rv, dest.Index(i).Interface())
+			}
+		}
+		outIdx++
+	}
+}
+
+// getMergedValues query the values generated by the database for the merged rows,
+// MERGE statement doesn't support RETURNING INTO, so the rows are matched by the conflict fields
+func getMergedValues(db *gorm.DB, conflictFields []*schema.Field) {
+	stmt := db.Statement
+	if stmt.Schema == nil || len(stmt.Schema.FieldsWithDefaultDBValue) == 0 || len(conflictFields) == 0 {
+		return
+	}
+
+	dataMap, conflictValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, conflictFields)
+	if len(conflictValues) == 0 {
+		return
+	}
+
+	var (
+		conflictNames = make([]string, len(conflictFields))
+		selectColumns = make([]string, 0, len(conflictFields)+len(stmt.Schema.FieldsWithDefaultDBValue))
+	)
+	for idx, field := range conflictFields {
+		conflictNames[idx] = field.DBName
+		selectColumns = append(selectColumns, field.DBName)
+	}
+	for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
+		if !utils.Contains(conflictNames, field.DBName) {
+			selectColumns = append(selectColumns, field.DBName)
+		}
+	}
+	if len(selectColumns) == len(conflictNames) {
+		// all the generated values are already known
+		return
+	}
+
+	column, values := schema.ToQueryValues(stmt.Table, conflictNames, conflictValues)
+	merged := reflect.New(reflect.SliceOf(stmt.Schema.ModelType))
+	if err := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Unscoped().
+		Table(stmt.Table).Select(selectColumns).
+		Where(clause.IN{Column: column, Values: values}).
+		Find(merged.Interface()).Error; db.AddError(err) != nil {
+		return
+	}
+
+	mergedValues := merged.Elem()
+	for i := 0; i < mergedValues.Len(); i++ {
+		mergedValue := mergedValues.Index(i)
+		keyValues := make([]interface{}, len(conflictFields))
+		for idx, field := range conflictFields {
+			keyValues[idx], _ = field.ValueOf(stmt.Context, mergedValue)
+		}
+		for _, insertTo := range dataMap[utils.ToStringKey(keyValues...)] {
+			for _, field := range stmt.Schema.FieldsWithDefaultDBValue {
+				if value, isZero := field.ValueOf(stmt.Context, mergedValue); !isZero {
+					setStructFieldValue(db, field, insertTo, value)
 				}
-			case reflect.Struct:
-				setStructFieldValue(db, insertTo, v)
-			default:
 			}
-		default:
 		}
 	}
 }
 
-func setStructFieldValue(db *gorm.DB, insertTo reflect.Value, out go_ora.Out) {
-	if _, isZero := db.Statement.Schema.PrioritizedPrimaryField.ValueOf(db.Statement.Context, insertTo); !isZero {
+func setStructFieldValue(db *gorm.DB, field *schema.Field, insertTo reflect.Value, value interface{}) {
+	if _, isZero := field.ValueOf(db.Statement.Context, insertTo); !isZero {
 		return
 	}
-	_ = db.AddError(db.Statement.Schema.PrioritizedPrimaryField.Set(db.Statement.Context, insertTo, out.Dest))
+	_ = db.AddError(field.Set(db.Statement.Context, insertTo, value))
 }
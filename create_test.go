@@ -5,6 +5,9 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	go_ora "github.com/sijms/go-ora/v2"
+	"gorm.io/gorm/clause"
 )
 
 func TestMergeCreate(t *testing.T) {
@@ -13,8 +16,7 @@ func TestMergeCreate(t *testing.T) {
 		t.Fatal(err)
 	}
 	if db == nil {
-		t.Log("db is nil!")
-		return
+		t.Skip("db is nil!")
 	}
 
 	model := TestTableUser{}
@@ -97,8 +99,7 @@ func TestMergeCreateUnique(t *testing.T) {
 		t.Fatal(err)
 	}
 	if db == nil {
-		t.Log("db is nil!")
-		return
+		t.Skip("db is nil!")
 	}
 
 	model := TestTableUserUnique{}
@@ -189,8 +190,7 @@ func TestOra03146TTC(t *testing.T) {
 		t.Fatal(err)
 	}
 	if db == nil {
-		t.Log("db is nil!")
-		return
+		t.Skip("db is nil!")
 	}
 
 	model := testModelOra03146TTC{}
@@ -254,8 +254,7 @@ func TestCreateInBatches(t *testing.T) {
 		t.Fatal(err)
 	}
 	if db == nil {
-		t.Log("db is nil!")
-		return
+		t.Skip("db is nil!")
 	}
 
 	model := testNoDefaultDBValues{}
@@ -283,7 +282,182 @@ func TestCreateInBatches(t *testing.T) {
 		if err = tx.Error; err != nil {
 			t.Fatal(err)
 		}
+		if tx.RowsAffected != int64(len(data)) {
+			t.Errorf("RowsAffected = %d, want %d", tx.RowsAffected, len(data))
+		}
 		dataJsonBytes, _ := json.MarshalIndent(data, "", "  ")
 		t.Logf("result: %s", dataJsonBytes)
 	})
 }
+
+func TestCreateInBatchesReturning(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	model := TestTableUser{}
+	migrator := db.Set("gorm:table_comments", "用户信息表").Migrator()
+	if migrator.HasTable(model) {
+		if err = migrator.DropTable(model); err != nil {
+			t.Fatalf("DropTable() error = %v", err)
+		}
+	}
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	} else {
+		t.Log("AutoMigrate() success!")
+	}
+
+	data := []TestTableUser{
+		{UID: "U1", Name: "Lisa", Account: "lisa", Password: "H6aLDNr", PhoneNumber: "+8616666666666", Sex: "0", UserType: 1, Enabled: true},
+		{UID: "U2", Name: "Daniela", Account: "daniela", Password: "Si7l1sRIC79", PhoneNumber: "+8619999999999", Sex: "1", UserType: 1, Enabled: true},
+		{UID: "U3", Name: "Tom", Account: "tom", Password: "********", PhoneNumber: "+8618888888888", Sex: "1", UserType: 1, Enabled: true},
+		{UID: "U4", Name: "James", Account: "james", Password: "********", PhoneNumber: "+8617777777777", Sex: "1", UserType: 2, Enabled: true},
+		{UID: "U5", Name: "John", Account: "john", Password: "********", PhoneNumber: "+8615555555555", Sex: "1", UserType: 1, Enabled: true},
+	}
+	t.Run("CreateInBatchesReturning", func(t *testing.T) {
+		tx := db.CreateInBatches(&data, 2)
+		if err = tx.Error; err != nil {
+			t.Fatal(err)
+		}
+		if tx.RowsAffected != int64(len(data)) {
+			t.Errorf("RowsAffected = %d, want %d", tx.RowsAffected, len(data))
+		}
+		for _, user := range data {
+			if user.ID == 0 {
+				t.Errorf("the generated ID of %s is not returned", user.UID)
+			}
+		}
+		dataJsonBytes, _ := json.MarshalIndent(data, "", "  ")
+		t.Logf("result: %s", dataJsonBytes)
+	})
+}
+
+type testMergeReturning struct {
+	ID        int64      `gorm:"column:id;primaryKey;autoIncrement:false" json:"id"`
+	Name      string     `gorm:"column:name;size:50" json:"name"`
+	CreatedAt *time.Time `gorm:"column:created_at;default:SYSTIMESTAMP" json:"createdAt"`
+}
+
+func (testMergeReturning) TableName() string {
+	return "test_merge_returning"
+}
+
+func TestMergeCreateReturning(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	model := testMergeReturning{}
+	migrator := db.Migrator()
+	if migrator.HasTable(model) {
+		if err = migrator.DropTable(model); err != nil {
+			t.Fatalf("DropTable() error = %v", err)
+		}
+	}
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+
+	data := []testMergeReturning{{ID: 1, Name: "Lisa"}, {ID: 2, Name: "Daniela"}}
+	t.Run("MergeCreateReturning", func(t *testing.T) {
+		if err = db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&data).Error; err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range data {
+			if row.CreatedAt == nil {
+				t.Errorf("the default value of row %d is not returned", row.ID)
+			}
+		}
+		dataJsonBytes, _ := json.MarshalIndent(data, "", "  ")
+		t.Logf("result: %s", dataJsonBytes)
+	})
+}
+
+func TestMergeCreateOnConflictColumns(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	model := TestTableUserUnique{}
+	migrator := db.Set("gorm:table_comments", "用户信息表").Migrator()
+	if migrator.HasTable(model) {
+		if err = migrator.DropTable(model); err != nil {
+			t.Fatalf("DropTable() error = %v", err)
+		}
+	}
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+
+	onConflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "uid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Neq{Column: clause.Column{Table: "excluded", Name: "name"}, Value: "Nobody"},
+		}},
+	}
+	data := []TestTableUserUnique{{UID: "U1", Name: "Lisa"}, {UID: "U2", Name: "Daniela"}}
+	if err = db.Clauses(onConflict).Create(&data).Error; err != nil {
+		t.Fatal(err)
+	}
+	upsert := []TestTableUserUnique{{UID: "U1", Name: "Lisa Updated"}, {UID: "U2", Name: "Nobody"}}
+	if err = db.Clauses(onConflict).Create(&upsert).Error; err != nil {
+		t.Fatal(err)
+	}
+	for i := range data {
+		if data[i].ID == 0 || upsert[i].ID != data[i].ID {
+			t.Errorf("ID of %s = %d after upsert, want %d", data[i].UID, upsert[i].ID, data[i].ID)
+		}
+	}
+
+	var got []TestTableUserUnique
+	if err = db.Order("uid").Find(&got).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Name != "Lisa Updated" || got[1].Name != "Daniela" {
+		t.Errorf("unexpected rows after upsert: %+v", got)
+	}
+}
+
+type testCreateNote struct {
+	Code string `gorm:"primaryKey;size:20"`
+	Body string `gorm:"type:CLOB"`
+}
+
+func TestCreateMixedClobBatch(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+	notes := []testCreateNote{
+		{Code: "N1", Body: strings.Repeat("x", 2001)},
+		{Code: "N2", Body: "short"},
+	}
+	if err := db.Create(&notes).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	statements := recorder.Statements()
+	if len(statements) != 1 {
+		t.Fatalf("the batch executed %d statements, want 1: %v", len(statements), statements)
+	}
+	column, ok := statements[0].Args[1].([]interface{})
+	if !ok || len(column) != len(notes) {
+		t.Fatalf("the bind of body = %#v, want an array of %d values", statements[0].Args[1], len(notes))
+	}
+	for idx, val := range column {
+		if clob, ok := val.(go_ora.Clob); !ok || clob.String != notes[idx].Body {
+			t.Errorf("the body of row %d is bound as %T, want go_ora.Clob of the same string", idx, val)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package oracle
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// JSONDataType is the gorm data type of JSON fields, it's mapped to JSON on Oracle 21c or later,
+// or CLOB with IS JSON check constraint on earlier versions
+const JSONDataType schema.DataType = "json"
+
+// JSON defined JSON data type of raw message, need to implement driver.Valuer, sql.Scanner interface
+type JSON json.RawMessage
+
+// Value return json value, implement driver.Valuer interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+// Scan value into JSON, implements sql.Scanner interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (j *JSON) Scan(val interface{}) error {
+	ba, err := jsonBytes(val)
+	*j = append((*j)[:0], ba...)
+	return err
+}
+
+// MarshalJSON to output non base64 encoded []byte
+//
+//goland:noinspection GoMixedReceiverTypes
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return json.RawMessage(j).MarshalJSON()
+}
+
+// UnmarshalJSON to deserialize []byte
+//
+//goland:noinspection GoMixedReceiverTypes
+func (j *JSON) UnmarshalJSON(b []byte) error {
+	return (*json.RawMessage)(j).UnmarshalJSON(b)
+}
+
+// String returns the JSON text
+//
+//goland:noinspection GoMixedReceiverTypes
+func (j JSON) String() string {
+	return string(j)
+}
+
+// GormDataType gorm common data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (JSON) GormDataType() string {
+	return string(JSONDataType)
+}
+
+// GormDBDataType gorm db data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (JSON) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonDBDataType(db, field)
+}
+
+// JSONMap defined JSON data type, need to implement driver.Valuer, sql.Scanner interface
+type JSONMap map[string]interface{}
+
+// Value return json value, implement driver.Valuer interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	ba, err := m.MarshalJSON()
+	return string(ba), err
+}
+
+// Scan value into Jsonb, implements sql.Scanner interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (m *JSONMap) Scan(val interface{}) error {
+	if val == nil {
+		*m = make(JSONMap)
+		return nil
+	}
+	ba, err := jsonBytes(val)
+	if err != nil {
+		return err
+	}
+	t := map[string]interface{}{}
+	rd := bytes.NewReader(ba)
+	decoder := json.NewDecoder(rd)
+	decoder.UseNumber()
+	err = decoder.Decode(&t)
+	*m = t
+	return err
+}
+
+// MarshalJSON to output non base64 encoded []byte
+//
+//goland:noinspection GoMixedReceiverTypes
+func (m JSONMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+	t := (map[string]interface{})(m)
+	return json.Marshal(t)
+}
+
+// UnmarshalJSON to deserialize []byte
+//
+//goland:noinspection GoMixedReceiverTypes
+func (m *JSONMap) UnmarshalJSON(b []byte) error {
+	t := map[string]interface{}{}
+	err := json.Unmarshal(b, &t)
+	*m = t
+	return err
+}
+
+// GormDataType gorm common data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (m JSONMap) GormDataType() string {
+	return string(JSONDataType)
+}
+
+// GormDBDataType gorm db data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (JSONMap) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonDBDataType(db, field)
+}
+
+// jsonDBDataType returns the db data type of JSON fields,
+// it's empty for Oracle so that the data type is decided by Dialector.DataTypeOf with the server capabilities
+func jsonDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "sqlite":
+		return "JSON"
+	case "mysql":
+		return "JSON"
+	case "postgres":
+		return "JSONB"
+	case "sqlserver":
+		return "NVARCHAR(MAX)"
+	case "oracle":
+		return ""
+	default:
+		return getGormTypeFromTag(field)
+	}
+}
+
+func getGormTypeFromTag(field *schema.Field) (dataType string) {
+	if field != nil {
+		if val, ok := field.TagSettings["TYPE"]; ok {
+			dataType = strings.ToLower(val)
+		}
+	}
+	return
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (m JSONMap) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	data, _ := m.MarshalJSON()
+	return gorm.Expr("?", string(data))
+}
+
+// jsonBytes returns the JSON text of scanned value
+func jsonBytes(val interface{}) ([]byte, error) {
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, errors.New(fmt.Sprint("Failed to unmarshal JSON value:", val))
+	}
+}
+
+// JSONSlice defined JSON data type of slice, need to implement driver.Valuer, sql.Scanner interface
+type JSONSlice[T any] []T
+
+// Value return json value, implement driver.Valuer interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (s JSONSlice[T]) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	ba, err := json.Marshal([]T(s))
+	return string(ba), err
+}
+
+// Scan value into JSONSlice, implements sql.Scanner interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (s *JSONSlice[T]) Scan(val interface{}) error {
+	ba, err := jsonBytes(val)
+	if err != nil || ba == nil {
+		*s = nil
+		return err
+	}
+	return json.Unmarshal(ba, (*[]T)(s))
+}
+
+// GormDataType gorm common data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (JSONSlice[T]) GormDataType() string {
+	return string(JSONDataType)
+}
+
+// GormDBDataType gorm db data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (JSONSlice[T]) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return jsonDBDataType(db, field)
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (s JSONSlice[T]) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	data, _ := s.Value()
+	return gorm.Expr("?", data)
+}
+
+// JSONQueryExpression is the JSON path condition of a JSON column
+//
+//	db.Where(oracle.JSONQuery("attrs").Equals("$.color", "red")).Find(&products)
+//	// SELECT * FROM "products" WHERE JSON_VALUE("attrs", '$.color') = 'red'
+type JSONQueryExpression struct {
+	column string
+	path   string
+	op     string
+	value  interface{}
+}
+
+// JSONQuery returns the JSON path condition builder of the column
+func JSONQuery(column string) *JSONQueryExpression {
+	return &JSONQueryExpression{column: column}
+}
+
+// Equals checks the scalar value at path with JSON_VALUE
+func (j *JSONQueryExpression) Equals(path string, value interface{}) *JSONQueryExpression {
+	j.op, j.path, j.value = "JSON_VALUE", path, value
+	return j
+}
+
+// Exists checks whether the path matches any value with JSON_EXISTS, e.g. `$.tags` or `$?(@.price > 10)`
+func (j *JSONQueryExpression) Exists(path string) *JSONQueryExpression {
+	j.op, j.path, j.value = "JSON_EXISTS", path, nil
+	return j
+}
+
+// EqualsJSON checks the object or array at path with JSON_QUERY, the value is compared in JSON text,
+// it's marshaled to JSON if it's not a string
+func (j *JSONQueryExpression) EqualsJSON(path string, value interface{}) *JSONQueryExpression {
+	j.op, j.path, j.value = "JSON_QUERY", path, value
+	return j
+}
+
+// Build implements clause.Expression
+func (j *JSONQueryExpression) Build(builder clause.Builder) {
+	if j.op == "" {
+		return
+	}
+
+	_, _ = builder.WriteString(j.op)
+	_ = builder.WriteByte('(')
+	builder.WriteQuoted(j.column)
+	_, _ = builder.WriteString(", ")
+	// JSON 路径必须是字符串字面量，不能使用绑定变量
+	_, _ = builder.WriteString(GetStringExpr(j.path, true).SQL)
+
+	switch j.op {
+	case "JSON_EXISTS":
+		_ = builder.WriteByte(')')
+	case "JSON_QUERY":
+		_, _ = builder.WriteString(" RETURNING VARCHAR2(4000)) = ")
+		value := j.value
+		if _, ok := value.(string); !ok {
+			ba, _ := json.Marshal(value)
+			value = string(ba)
+		}
+		builder.AddVar(builder, value)
+	default:
+		_, _ = builder.WriteString(") = ")
+		builder.AddVar(builder, j.value)
+	}
+}
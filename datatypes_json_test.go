@@ -0,0 +1,88 @@
+package oracle
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testJSONProduct struct {
+	ID    uint64 `gorm:"primaryKey"`
+	Name  string `gorm:"size:50"`
+	Attrs JSONMap
+	Tags  JSONSlice[string] `gorm:"not null"`
+	Extra JSON
+}
+
+func (testJSONProduct) TableName() string {
+	return "test_json_product"
+}
+
+func TestJSONTypes(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		var j JSON
+		if err := j.Scan(`{"a":1}`); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := j.Value(); err != nil || v != `{"a":1}` {
+			t.Errorf("Value() = %v, %v", v, err)
+		}
+		if err := j.Scan(nil); err != nil || len(j) != 0 {
+			t.Errorf("Scan(nil) = %v, %v", j, err)
+		}
+		if v, _ := j.Value(); v != nil {
+			t.Errorf("Value() of empty JSON = %v, want nil", v)
+		}
+	})
+	t.Run("JSONMap", func(t *testing.T) {
+		var m JSONMap
+		if err := m.Scan([]byte(`{"color":"red","size":10}`)); err != nil {
+			t.Fatal(err)
+		}
+		if m["color"] != "red" || m["size"].(interface{ String() string }).String() != "10" {
+			t.Errorf("Scan() = %v", m)
+		}
+		if err := m.Scan(1); err == nil {
+			t.Error("Scan(1) should fail")
+		}
+	})
+	t.Run("JSONSlice", func(t *testing.T) {
+		s := JSONSlice[string]{"a", "b"}
+		v, err := s.Value()
+		if err != nil || v != `["a","b"]` {
+			t.Fatalf("Value() = %v, %v", v, err)
+		}
+		var got JSONSlice[string]
+		if err = got.Scan(v); err != nil || !reflect.DeepEqual(got, s) {
+			t.Errorf("Scan() = %v, %v, want %v", got, err, s)
+		}
+		if v, _ = JSONSlice[int](nil).Value(); v != nil {
+			t.Errorf("Value() of nil slice = %v, want nil", v)
+		}
+	})
+}
+
+func TestGoldenJSON(t *testing.T) {
+	for _, version := range []string{"21.3.0.0.0", "19.0.0.0.0"} {
+		t.Run(strings.Split(version, ".")[0], func(t *testing.T) {
+			db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, version)
+			if err := db.Migrator().CreateTable(&testJSONProduct{}); err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Create(&testJSONProduct{Name: "pen", Attrs: JSONMap{"color": "red"}, Tags: JSONSlice[string]{"a"}}).Error; err != nil {
+				t.Fatal(err)
+			}
+			var products []testJSONProduct
+			if err := db.Where(JSONQuery("attrs").Equals("$.color", "red")).
+				Where(JSONQuery("attrs").Exists(`$?(@.size > 10)`)).
+				Where(JSONQuery("tags").EqualsJSON("$", []string{"a"})).
+				Find(&products).Error; err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Select("id", "attrs").Find(&products).Error; err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, recorder)
+		})
+	}
+}
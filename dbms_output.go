@@ -0,0 +1,209 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"time"
+
+	"github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+)
+
+// DBMSOutputKey is the setting key to capture the output of DBMS_OUTPUT for the session, see Config.DBMSOutput
+//
+//	tx := db.Set(oracle.DBMSOutputKey, true).Exec("BEGIN PKG_ORDER.SUBMIT(?); END;", orderID)
+//	lines := oracle.DBMSOutputLines(tx)
+const DBMSOutputKey = "oracle:dbms_output"
+
+const (
+	dbmsOutputStateKey = "oracle:dbms_output_state"
+	dbmsOutputLinesKey = "oracle:dbms_output_lines"
+	// dbmsOutputBatchSize is the number of lines fetched by DBMS_OUTPUT.GET_LINES at a time
+	dbmsOutputBatchSize = 100
+	// dbmsOutputMarker is put to the buffer of DBMS_OUTPUT to detect whether it's enabled
+	dbmsOutputMarker = "gorm-oracle:dbms_output"
+	// sessionResetTimeout is the timeout of the statements restoring the session state after the statement
+	sessionResetTimeout = 5 * time.Second
+)
+
+// enableDBMSOutput enables DBMS_OUTPUT with unlimited buffer, :2 returns the number of lines in the buffer
+// including the marker, which is 0 if it's disabled before. The lines put before are put back, so they're
+// kept for the session that enabled it, and the marker is removed from the line put without new line.
+//
+//goland:noinspection SqlNoDataSourceInspection
+const enableDBMSOutput = `DECLARE
+	l_marker VARCHAR2(100) := :1;
+	l_lines  DBMSOUTPUT_LINESARRAY;
+	l_count  INTEGER := 2147483647;
+BEGIN
+	DBMS_OUTPUT.PUT_LINE(l_marker);
+	DBMS_OUTPUT.GET_LINES(l_lines, l_count);
+	:2 := l_count;
+	FOR i IN 1 .. l_count - 1 LOOP
+		DBMS_OUTPUT.PUT_LINE(l_lines(i));
+	END LOOP;
+	IF l_count > 0 AND LENGTH(l_lines(l_count)) > LENGTH(l_marker) THEN
+		DBMS_OUTPUT.PUT(SUBSTR(l_lines(l_count), 1, LENGTH(l_lines(l_count)) - LENGTH(l_marker)));
+	END IF;
+	DBMS_OUTPUT.ENABLE(NULL);
+END;`
+
+// DBMSOutputLines returns the lines of DBMS_OUTPUT captured by the Exec of tx
+func DBMSOutputLines(tx *gorm.DB) []string {
+	if lines, ok := tx.InstanceGet(dbmsOutputLinesKey); ok {
+		return lines.([]string)
+	}
+	return nil
+}
+
+// useDBMSOutput reports whether the output of DBMS_OUTPUT is captured for the statement
+func useDBMSOutput(db *gorm.DB) bool {
+	if db.DryRun || db.Statement.ConnPool == nil {
+		return false
+	}
+	if v, ok := db.Get(DBMSOutputKey); ok {
+		enabled, _ := v.(bool)
+		return enabled
+	}
+	d, ok := ptrDereference(db.Dialector).(Dialector)
+	return ok && d.Config != nil && d.DBMSOutput
+}
+
+// pinnedConn is the connection of the pool pinned for the statement, because the session state set before
+// the statement, like the buffer of DBMS_OUTPUT, belongs to the connection
+type pinnedConn struct {
+	// pool is the original ConnPool of the statement, which is replaced by conn
+	pool gorm.ConnPool
+	conn *sql.Conn
+	// broken is set if the session state isn't restored, the connection is discarded instead of being pooled
+	broken bool
+	// outputEnabled is whether DBMS_OUTPUT is enabled on the session before the statement
+	outputEnabled bool
+}
+
+// pooledDB returns the *sql.DB of the ConnPool if it's a pool, including the *gorm.PreparedStmtDB of
+// PrepareStmt mode, or nil for the transactions and connections
+func pooledDB(pool gorm.ConnPool) *sql.DB {
+	switch p := pool.(type) {
+	case *sql.DB:
+		return p
+	case gorm.TxCommitter:
+		return nil
+	case gorm.GetDBConnector:
+		if sqlDB, err := p.GetDBConn(); err == nil {
+			return sqlDB
+		}
+	}
+	return nil
+}
+
+// pinConn replaces the ConnPool of the statement with a dedicated connection if it's a pool,
+// the transactions and connections are kept as they are
+func pinConn(db *gorm.DB) (*pinnedConn, error) {
+	pinned := &pinnedConn{pool: db.Statement.ConnPool}
+	if sqlDB := pooledDB(db.Statement.ConnPool); sqlDB != nil {
+		conn, err := sqlDB.Conn(db.Statement.Context)
+		if err != nil {
+			return nil, err
+		}
+		pinned.conn = conn
+		db.Statement.ConnPool = conn
+	}
+	return pinned, nil
+}
+
+// reset executes the statement restoring the session state after the statement, it's executed on a context
+// which isn't canceled with the statement, and the pinned connection is discarded if it fails
+func (p *pinnedConn) reset(db *gorm.DB, query string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sessionResetTimeout)
+	defer cancel()
+	_, err := db.Statement.ConnPool.ExecContext(ctx, query)
+	if err != nil {
+		p.broken = true
+	}
+	return err
+}
+
+// release returns the dedicated connection to the pool and restores the ConnPool of the statement,
+// the broken connection is closed instead
+func (p *pinnedConn) release(db *gorm.DB) {
+	if p.conn != nil {
+		if p.broken {
+			_ = p.conn.Raw(func(interface{}) error {
+				return driver.ErrBadConn
+			})
+		}
+		_ = p.conn.Close()
+		db.Statement.ConnPool = p.pool
+	}
+}
+
+// beforeDBMSOutput enables DBMS_OUTPUT before the raw statement, the statement is executed
+// on a dedicated connection of the pool, because the buffer of DBMS_OUTPUT belongs to the session
+func beforeDBMSOutput(db *gorm.DB) {
+	if db.Error != nil || !useDBMSOutput(db) {
+		return
+	}
+	pinned, err := pinConn(db)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	db.InstanceSet(dbmsOutputStateKey, pinned)
+	var count int
+	if _, err = db.Statement.ConnPool.ExecContext(db.Statement.Context, enableDBMSOutput,
+		dbmsOutputMarker, go_ora.Out{Dest: &count}); err != nil {
+		_ = db.AddError(err)
+	}
+	pinned.outputEnabled = count > 0
+}
+
+// afterDBMSOutput drains the buffer of DBMS_OUTPUT after the raw statement even if it fails,
+// the lines are logged at info level and kept for DBMSOutputLines. DBMS_OUTPUT is disabled then
+// unless it's enabled before, so the connection returned to the pool doesn't buffer the output.
+func afterDBMSOutput(db *gorm.DB) {
+	v, ok := db.InstanceGet(dbmsOutputStateKey)
+	if !ok {
+		return
+	}
+	pinned := v.(*pinnedConn)
+	defer pinned.release(db)
+
+	lines, err := getDBMSOutputLines(db)
+	if err != nil {
+		db.Logger.Warn(db.Statement.Context, "failed to get the lines of DBMS_OUTPUT: %v", err)
+	}
+	if !pinned.outputEnabled {
+		if err = pinned.reset(db, "BEGIN DBMS_OUTPUT.DISABLE; END;"); err != nil {
+			db.Logger.Warn(db.Statement.Context, "failed to disable DBMS_OUTPUT: %v", err)
+		}
+	}
+	for _, line := range lines {
+		db.Logger.Info(db.Statement.Context, "DBMS_OUTPUT: %s", line)
+	}
+	db.InstanceSet(dbmsOutputLinesKey, lines)
+}
+
+// getDBMSOutputLines gets all the lines in the buffer of DBMS_OUTPUT by DBMS_OUTPUT.GET_LINES
+func getDBMSOutputLines(db *gorm.DB) (lines []string, err error) {
+	for {
+		var batch []string
+		numLines := dbmsOutputBatchSize
+		if _, err = db.Statement.ConnPool.ExecContext(db.Statement.Context,
+			"BEGIN DBMS_OUTPUT.GET_LINES(:1, :2); END;",
+			go_ora.Out{Dest: &batch, Size: dbmsOutputBatchSize},
+			go_ora.Out{Dest: &numLines, In: true},
+		); err != nil {
+			return
+		}
+		n := numLines
+		if n > len(batch) {
+			n = len(batch)
+		}
+		lines = append(lines, batch[:n]...)
+		if n < dbmsOutputBatchSize {
+			return
+		}
+	}
+}
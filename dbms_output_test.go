@@ -0,0 +1,148 @@
+package oracle
+
+import (
+	"bytes"
+	"database/sql"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+const testDBMSOutputBlock = `BEGIN
+	DBMS_OUTPUT.PUT_LINE('order ' || :1 || ' submitted');
+	DBMS_OUTPUT.PUT_LINE('done');
+END;`
+
+func TestGoldenDBMSOutput(t *testing.T) {
+	t.Run("Config", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{DBMSOutput: true}, "19.0.0.0.0")
+		recorder.Script(`^BEGIN DBMS_OUTPUT\.GET_LINES`, oracletest.Result{
+			Out: []interface{}{[]string{"order 1001 submitted", "done"}, 2},
+		})
+
+		var buf bytes.Buffer
+		tx := db.Session(&gorm.Session{Logger: logger.New(log.New(&buf, "", 0), logger.Config{LogLevel: logger.Info})}).
+			Exec(testDBMSOutputBlock, 1001)
+		if tx.Error != nil {
+			t.Fatal(tx.Error)
+		}
+		if got, want := DBMSOutputLines(tx), []string{"order 1001 submitted", "done"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("DBMSOutputLines() = %v, want %v", got, want)
+		}
+		if !strings.Contains(buf.String(), "DBMS_OUTPUT: order 1001 submitted") {
+			t.Errorf("the lines of DBMS_OUTPUT are not logged:\n%s", buf.String())
+		}
+		if _, ok := tx.Statement.ConnPool.(*sql.DB); !ok {
+			t.Errorf("the connection pool is not restored, got %T", tx.Statement.ConnPool)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Session", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{}, "19.0.0.0.0")
+		tx := db.Set(DBMSOutputKey, true).Exec(testDBMSOutputBlock, 1002)
+		if tx.Error != nil {
+			t.Fatal(tx.Error)
+		}
+		if lines := DBMSOutputLines(tx); len(lines) != 0 {
+			t.Errorf("DBMSOutputLines() = %v, want no lines", lines)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{}, "19.0.0.0.0")
+		// DBMS_OUTPUT is enabled before, the buffer returns the marker
+		recorder.Script(`^DECLARE`, oracletest.Result{Out: []interface{}{1}})
+		tx := db.Begin()
+		if err := tx.Exec(`BEGIN DBMS_OUTPUT.ENABLE; END;`).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Set(DBMSOutputKey, true).Exec(testDBMSOutputBlock, 1004).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Commit().Error; err != nil {
+			t.Fatal(err)
+		}
+		for _, stmt := range recorder.Statements() {
+			if strings.Contains(stmt.SQL, "DBMS_OUTPUT.DISABLE") {
+				t.Errorf("DBMS_OUTPUT enabled before is disabled: %v", recorder.Statements())
+			}
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{DBMSOutput: true}, "19.0.0.0.0")
+		tx := db.Set(DBMSOutputKey, false).Exec(testDBMSOutputBlock, 1003)
+		if tx.Error != nil {
+			t.Fatal(tx.Error)
+		}
+		if lines := DBMSOutputLines(tx); lines != nil {
+			t.Errorf("DBMSOutputLines() = %v, want nil", lines)
+		}
+		assertGolden(t, recorder)
+	})
+}
+
+func TestPinConn(t *testing.T) {
+	db, _ := openRecorder(t, Config{}, "19.0.0.0.0")
+	tests := []struct {
+		name       string
+		tx         *gorm.DB
+		wantPinned bool
+	}{
+		{name: "Pool", tx: db.Session(&gorm.Session{}), wantPinned: true},
+		{name: "PrepareStmt", tx: db.Session(&gorm.Session{PrepareStmt: true}), wantPinned: true},
+		{name: "Transaction", tx: db.Begin()},
+		{name: "PrepareStmtTransaction", tx: db.Session(&gorm.Session{PrepareStmt: true}).Begin()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := tt.tx.Statement.ConnPool
+			pinned, err := pinConn(tt.tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := tt.tx.Statement.ConnPool.(*sql.Conn); ok != tt.wantPinned {
+				t.Errorf("pinConn() pinned = %v, want %v, ConnPool %T", ok, tt.wantPinned, pool)
+			}
+			pinned.release(tt.tx)
+			if tt.tx.Statement.ConnPool != pool {
+				t.Errorf("release() ConnPool = %T, want %T", tt.tx.Statement.ConnPool, pool)
+			}
+			if !tt.wantPinned {
+				tt.tx.Rollback()
+			}
+		})
+	}
+}
+
+func TestDBMSOutput(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	tx := db.Set(DBMSOutputKey, true).Exec(`
+	BEGIN
+		FOR i IN 1..150 LOOP
+			DBMS_OUTPUT.PUT_LINE('line ' || i);
+		END LOOP;
+	END;`)
+	if tx.Error != nil {
+		t.Fatal(tx.Error)
+	}
+	lines := DBMSOutputLines(tx)
+	if len(lines) != 150 || lines[0] != "line 1" || lines[149] != "line 150" {
+		t.Errorf("got %d lines of DBMS_OUTPUT: %v", len(lines), lines)
+	}
+}
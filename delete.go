@@ -0,0 +1,86 @@
+package oracle
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+	"gorm.io/gorm/utils"
+)
+
+func Delete(config *callbacks.Config) func(db *gorm.DB) {
+	supportReturning := utils.Contains(config.DeleteClauses, "RETURNING")
+
+	return func(db *gorm.DB) {
+		if db.Error != nil {
+			return
+		}
+
+		stmt := db.Statement
+		if stmt == nil {
+			return
+		}
+
+		if stmtSchema := stmt.Schema; stmtSchema != nil {
+			for _, c := range stmtSchema.DeleteClauses {
+				stmt.AddClause(c)
+			}
+		}
+
+		if stmt.SQL.Len() == 0 {
+			stmt.SQL.Grow(100)
+			stmt.AddClauseIfNotExists(clause.Delete{})
+
+			if stmtSchema := stmt.Schema; stmtSchema != nil {
+				_, queryValues := schema.GetIdentityFieldValuesMap(stmt.Context, stmt.ReflectValue, stmtSchema.PrimaryFields)
+				column, values := schema.ToQueryValues(stmt.Table, stmtSchema.PrimaryFieldDBNames, queryValues)
+
+				if len(values) > 0 {
+					stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+				}
+
+				if stmt.ReflectValue.CanAddr() && stmt.Dest != stmt.Model && stmt.Model != nil {
+					_, queryValues = schema.GetIdentityFieldValuesMap(stmt.Context, reflect.ValueOf(stmt.Model), stmtSchema.PrimaryFields)
+					column, values = schema.ToQueryValues(stmt.Table, stmtSchema.PrimaryFieldDBNames, queryValues)
+
+					if len(values) > 0 {
+						stmt.AddClause(clause.Where{Exprs: []clause.Expression{clause.IN{Column: column, Values: values}}})
+					}
+				}
+			}
+
+			stmt.AddClauseIfNotExists(clause.From{})
+
+			stmt.Build(stmt.BuildClauses...)
+		}
+
+		checkMissingWhereConditions(db)
+
+		if !db.DryRun && db.Error == nil {
+			nativeBoolean := getNativeBoolean(db)
+			for i, val := range stmt.Vars {
+				stmt.Vars[i] = convertValue(val, nativeBoolean)
+			}
+			if ok, mode := hasReturning(db, supportReturning); ok {
+				// Oracle returns the values of deleted rows through out-binds, not a result set
+				execReturning(db, reflect.ValueOf(stmt.Dest), mode)
+				if stmt.Result != nil {
+					stmt.Result.RowsAffected = db.RowsAffected
+				}
+				return
+			}
+
+			result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+			if db.AddError(err) == nil {
+				db.RowsAffected, _ = result.RowsAffected()
+
+				if stmt.Result != nil {
+					stmt.Result.Result = result
+					stmt.Result.RowsAffected = db.RowsAffected
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package oracle
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+
+	"github.com/sijms/go-ora/v2/network"
+	"gorm.io/gorm"
+)
+
+// Oracle error codes translated to GORM errors
+const (
+	ErrCodeUniqueViolation   = 1    // ORA-00001: unique constraint violated
+	ErrCodeCheckViolation    = 2290 // ORA-02290: check constraint violated
+	ErrCodeParentKeyNotFound = 2291 // ORA-02291: integrity constraint violated - parent key not found
+	ErrCodeChildRecordFound  = 2292 // ORA-02292: integrity constraint violated - child record found
+	// ErrCodeSerializationFailure is ORA-08177: can't serialize access for this transaction
+	ErrCodeSerializationFailure = 8177
+)
+
+// ErrSerializationFailure is the error of the serializable transactions that conflict with others,
+// they could be retried, see Transaction. The Oracle errors match it with errors.Is when
+// gorm.Config.TranslateError is true, otherwise check the code returned by AsOracleError.
+var ErrSerializationFailure = errors.New("serialization failure")
+
+var (
+	oraCodePattern       = regexp.MustCompile(`ORA-(\d{5})`)
+	oraConstraintPattern = regexp.MustCompile(`\(([^()\s]+)\)`)
+)
+
+// OracleError is an error returned by the Oracle database server
+type OracleError struct {
+	// Code is the number of the ORA-NNNNN error
+	Code int
+	// Constraint is the name of the violated constraint, qualified with its owner, e.g. SCHEMA.UK_NAME
+	Constraint string
+	// Message is the original error message
+	Message string
+
+	err error
+}
+
+func (e *OracleError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the original error of the driver
+func (e *OracleError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether the error matches the GORM error it translates to,
+// so that errors.Is(err, gorm.ErrDuplicatedKey) works when TranslateError is enabled
+func (e *OracleError) Is(target error) bool {
+	translated := e.translate()
+	return translated != nil && translated == target
+}
+
+func (e *OracleError) translate() error {
+	switch e.Code {
+	case ErrCodeUniqueViolation:
+		return gorm.ErrDuplicatedKey
+	case ErrCodeParentKeyNotFound, ErrCodeChildRecordFound:
+		return gorm.ErrForeignKeyViolated
+	case ErrCodeCheckViolation:
+		return gorm.ErrCheckConstraintViolated
+	case ErrCodeSerializationFailure:
+		return ErrSerializationFailure
+	default:
+		return nil
+	}
+}
+
+// AsOracleError finds the first Oracle server error in err's chain,
+// the error messages of other drivers are parsed for the ORA-NNNNN code
+func AsOracleError(err error) (oraErr *OracleError, ok bool) {
+	if err == nil {
+		return
+	}
+	if ok = errors.As(err, &oraErr); ok {
+		return
+	}
+
+	oraErr = &OracleError{Message: err.Error(), err: err}
+	var driverErr *network.OracleError
+	if errors.As(err, &driverErr) {
+		oraErr.Code = driverErr.ErrCode
+		oraErr.Message = driverErr.Error()
+	} else if m := oraCodePattern.FindStringSubmatch(oraErr.Message); len(m) > 1 {
+		oraErr.Code, _ = strconv.Atoi(m[1])
+	} else {
+		return nil, false
+	}
+	if m := oraConstraintPattern.FindStringSubmatch(oraErr.Message); len(m) > 1 {
+		oraErr.Constraint = m[1]
+	}
+	return oraErr, true
+}
+
+// Translate implements gorm.ErrorTranslator interface,
+// it's called by GORM when gorm.Config.TranslateError is true
+//
+// The Oracle errors that have a GORM equivalent are returned as *OracleError,
+// which matches the GORM error with errors.Is:
+//
+//	ORA-00001          gorm.ErrDuplicatedKey
+//	ORA-02291/02292    gorm.ErrForeignKeyViolated
+//	ORA-02290          gorm.ErrCheckConstraintViolated
+//	ORA-08177          ErrSerializationFailure
+func (d Dialector) Translate(err error) error {
+	if oraErr, ok := AsOracleError(err); ok && oraErr.translate() != nil {
+		return oraErr
+	}
+	return err
+}
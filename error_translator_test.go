@@ -0,0 +1,90 @@
+package oracle
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sijms/go-ora/v2/network"
+	"gorm.io/gorm"
+)
+
+func TestDialector_Translate(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantErr        error
+		wantCode       int
+		wantConstraint string
+	}{
+		{
+			name:           "DuplicatedKey",
+			err:            &network.OracleError{ErrCode: 1, ErrMsg: "ORA-00001: unique constraint (SCOTT.UK_USER_UID) violated"},
+			wantErr:        gorm.ErrDuplicatedKey,
+			wantCode:       1,
+			wantConstraint: "SCOTT.UK_USER_UID",
+		},
+		{
+			name:           "DuplicatedKeyLocalized",
+			err:            fmt.Errorf("insert failed: %w", errors.New("ORA-00001: 违反唯一约束条件 (SYSTEM.SYS_C0011)")),
+			wantErr:        gorm.ErrDuplicatedKey,
+			wantCode:       1,
+			wantConstraint: "SYSTEM.SYS_C0011",
+		},
+		{
+			name:           "ParentKeyNotFound",
+			err:            &network.OracleError{ErrCode: 2291, ErrMsg: "ORA-02291: integrity constraint (SCOTT.FK_ORDER_USER) violated - parent key not found"},
+			wantErr:        gorm.ErrForeignKeyViolated,
+			wantCode:       2291,
+			wantConstraint: "SCOTT.FK_ORDER_USER",
+		},
+		{
+			name:           "ChildRecordFound",
+			err:            &network.OracleError{ErrCode: 2292, ErrMsg: "ORA-02292: integrity constraint (SCOTT.FK_ORDER_USER) violated - child record found"},
+			wantErr:        gorm.ErrForeignKeyViolated,
+			wantCode:       2292,
+			wantConstraint: "SCOTT.FK_ORDER_USER",
+		},
+		{
+			name:           "CheckConstraintViolated",
+			err:            &network.OracleError{ErrCode: 2290, ErrMsg: "ORA-02290: check constraint (SCOTT.CK_USER_AGE) violated"},
+			wantErr:        gorm.ErrCheckConstraintViolated,
+			wantCode:       2290,
+			wantConstraint: "SCOTT.CK_USER_AGE",
+		},
+		{
+			name:     "SerializationFailure",
+			err:      &network.OracleError{ErrCode: 8177, ErrMsg: "ORA-08177: can't serialize access for this transaction"},
+			wantErr:  ErrSerializationFailure,
+			wantCode: 8177,
+		},
+		{
+			name:     "NotTranslated",
+			err:      &network.OracleError{ErrCode: 942, ErrMsg: "ORA-00942: table or view does not exist"},
+			wantCode: 942,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Dialector{}.Translate(tt.err)
+			if tt.wantErr == nil {
+				if got != tt.err {
+					t.Errorf("Translate() = %v, want the original error", got)
+				}
+			} else if !errors.Is(got, tt.wantErr) {
+				t.Errorf("Translate() = %v, want errors.Is %v", got, tt.wantErr)
+			}
+
+			oraErr, ok := AsOracleError(got)
+			if !ok {
+				t.Fatalf("AsOracleError() of %v failed", got)
+			}
+			if oraErr.Code != tt.wantCode {
+				t.Errorf("Code = %d, want %d", oraErr.Code, tt.wantCode)
+			}
+			if oraErr.Constraint != tt.wantConstraint {
+				t.Errorf("Constraint = %s, want %s", oraErr.Constraint, tt.wantConstraint)
+			}
+		})
+	}
+}
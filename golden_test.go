@@ -0,0 +1,264 @@
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/migrator"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of SQL generation tests")
+
+// openRecorder opens an offline database connection recording the executed statements
+func openRecorder(t *testing.T, config Config, version string) (*gorm.DB, *oracletest.Recorder) {
+	recorder := oracletest.NewRecorder()
+	recorder.Version = version
+	config.Conn = recorder.DB()
+	db, err := gorm.Open(New(config), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+		NowFunc: func() time.Time {
+			return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		},
+	})
+	if err != nil {
+		t.Fatalf("open recorder error = %v", err)
+	}
+	recorder.Reset()
+	return db, recorder
+}
+
+// assertGolden compares the recorded statements with testdata/<test name>.golden,
+// run `go test -run TestGolden -update` to update the golden files
+func assertGolden(t *testing.T, recorder *oracletest.Recorder) {
+	t.Helper()
+	got := recorder.String()
+	path := filepath.Join("testdata", strings.ReplaceAll(t.Name(), "/", "_")+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file error = %v, run with -update to create it", err)
+	}
+	if got != string(want) {
+		t.Errorf("statements of %s mismatch golden file %s\ngot:\n%s\nwant:\n%s", t.Name(), path, got, want)
+	}
+}
+
+func TestGoldenCreate(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+	users := []TestTableUser{
+		{UID: "U1", Name: "Lisa", Account: "lisa", UserType: 1, Enabled: true},
+		{UID: "U2", Name: "Daniela", Account: "daniela", UserType: 1},
+	}
+
+	t.Run("Single", func(t *testing.T) {
+		recorder.Reset()
+		if err := db.Create(&TestTableUser{UID: "U0", Name: "Tom", Enabled: true}).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("Batch", func(t *testing.T) {
+		recorder.Reset()
+		if err := db.Create(&users).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("Merge", func(t *testing.T) {
+		recorder.Reset()
+		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "uid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name"}),
+		}).Create(&users).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+}
+
+func TestGoldenUpdateDelete(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+
+	t.Run("Update", func(t *testing.T) {
+		recorder.Reset()
+		if err := db.Model(&TestTableUser{ID: 1}).Updates(map[string]interface{}{"name": "Lisa", "enabled": false}).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("UpdateReturning", func(t *testing.T) {
+		recorder.Reset()
+		var users []TestTableUser
+		if err := db.Model(&users).Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("user_type = ?", 1).Update("user_type", 2).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		recorder.Reset()
+		if err := db.Delete(&TestTableUser{}, []uint64{1, 2}).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+}
+
+func TestGoldenLimit(t *testing.T) {
+	for _, version := range []string{"23.4.0.24.05", "19.0.0.0.0", "11.2.0.4.0"} {
+		t.Run(strings.Split(version, ".")[0], func(t *testing.T) {
+			db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, version)
+			var users []TestTableUser
+			if err := db.Where("user_type = ?", 1).Order("id").Offset(10).Limit(5).Find(&users).Error; err != nil {
+				t.Fatal(err)
+			}
+			if err := db.Limit(1).Find(&users).Error; err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, recorder)
+		})
+	}
+}
+
+func TestGoldenMigrator(t *testing.T) {
+	for _, version := range []string{"23.4.0.24.05", "19.0.0.0.0", "11.2.0.4.0"} {
+		t.Run(strings.Split(version, ".")[0], func(t *testing.T) {
+			db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, version)
+			if err := db.Migrator().CreateTable(&TestTableUser{}); err != nil {
+				t.Fatal(err)
+			}
+			assertGolden(t, recorder)
+		})
+	}
+}
+
+func TestGoldenCapabilities(t *testing.T) {
+	users := []TestTableUser{
+		{UID: "U1", Name: "Lisa", Account: "lisa", UserType: 1},
+		{UID: "U2", Name: "Daniela", Account: "daniela", UserType: 1},
+	}
+
+	t.Run("Merge23", func(t *testing.T) {
+		db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "23.4.0.24.05")
+		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "uid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name"}),
+		}).Create(&users).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("Override", func(t *testing.T) {
+		// the overridden capabilities take precedence over the detected server version
+		db, recorder := openRecorder(t, Config{NamingCaseSensitive: true, Capabilities: &Capabilities{}}, "19.0.0.0.0")
+		var found []TestTableUser
+		if err := db.Order("id").Offset(10).Limit(5).Find(&found).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Migrator().CreateTable(&TestTableUser{}); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+}
+
+func TestGoldenNativeBoolean(t *testing.T) {
+	config := Config{NamingCaseSensitive: true, NativeBoolean: true}
+
+	t.Run("CreateTable", func(t *testing.T) {
+		db, recorder := openRecorder(t, config, "23.4.0.24.05")
+		if err := db.Migrator().CreateTable(&TestTableUser{}); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("Create", func(t *testing.T) {
+		db, recorder := openRecorder(t, config, "23.4.0.24.05")
+		if err := db.Create(&TestTableUser{UID: "U1", Name: "Lisa", Enabled: true}).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Model(&TestTableUser{ID: 1}).Update("enabled", false).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("MigrateColumn", func(t *testing.T) {
+		db, recorder := openRecorder(t, config, "23.4.0.24.05")
+		recorder.Script(`TAB_COLUMNS WHERE`, oracletest.Result{
+			Columns: []string{"COUNT"}, Rows: [][]driver.Value{{int64(1)}},
+		})
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(&TestTableUser{}); err != nil {
+			t.Fatal(err)
+		}
+		columnType := migrator.ColumnType{
+			NameValue:         sql.NullString{String: "enabled", Valid: true},
+			DataTypeValue:     sql.NullString{String: "NUMBER", Valid: true},
+			ColumnTypeValue:   sql.NullString{String: "NUMBER(1)", Valid: true},
+			NullableValue:     sql.NullBool{Bool: true, Valid: true},
+			DefaultValueValue: sql.NullString{},
+		}
+		if err := db.Migrator().MigrateColumn(&TestTableUser{}, stmt.Schema.LookUpField("enabled"), columnType); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("MigrateColumnConstraints", func(t *testing.T) {
+		type testNativeBooleanFlag struct {
+			ID     uint64 `gorm:"primaryKey"`
+			Active bool   `gorm:"not null;default:true"`
+		}
+		db, recorder := openRecorder(t, config, "23.4.0.24.05")
+		recorder.Script(`SELECT NULLABLE FROM`, oracletest.Result{
+			Columns: []string{"NULLABLE"}, Rows: [][]driver.Value{{"Y"}},
+		})
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(&testNativeBooleanFlag{}); err != nil {
+			t.Fatal(err)
+		}
+		// the NUMBER column isn't converted, but its nullability and default value are still migrated
+		columnType := migrator.ColumnType{
+			NameValue:         sql.NullString{String: "active", Valid: true},
+			DataTypeValue:     sql.NullString{String: "NUMBER", Valid: true},
+			ColumnTypeValue:   sql.NullString{String: "NUMBER(1)", Valid: true},
+			NullableValue:     sql.NullBool{Bool: true, Valid: true},
+			DefaultValueValue: sql.NullString{},
+		}
+		if err := db.Migrator().MigrateColumn(&testNativeBooleanFlag{}, stmt.Schema.LookUpField("active"), columnType); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+	t.Run("Explain", func(t *testing.T) {
+		db, _ := openRecorder(t, config, "23.4.0.24.05")
+		got := db.Dialector.Explain(`SELECT * FROM "test_user" WHERE "enabled" = :1 AND "user_type" = :2`, true, 1)
+		if want := `SELECT * FROM "test_user" WHERE "enabled" = TRUE AND "user_type" = 1`; got != want {
+			t.Errorf("Explain() = %s, want %s", got, want)
+		}
+		db, _ = openRecorder(t, config, "19.0.0.0.0")
+		got = db.Dialector.Explain(`SELECT * FROM "test_user" WHERE "enabled" = :1`, true)
+		if want := `SELECT * FROM "test_user" WHERE "enabled" = 1`; got != want {
+			t.Errorf("Explain() = %s, want %s", got, want)
+		}
+	})
+}
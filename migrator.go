@@ -2,9 +2,13 @@ package oracle
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -56,20 +60,18 @@ func (m Migrator) AutoMigrate(dst ...interface{}) error {
 func (m Migrator) FullDataTypeOf(field *schema.Field) (expr clause.Expr) {
 	expr.SQL = m.DataTypeOf(field)
 
-	if field.HasDefaultValue && (field.DefaultValueInterface != nil || field.DefaultValue != "") {
-		if field.DefaultValueInterface != nil {
-			defaultStmt := &gorm.Statement{Vars: []interface{}{field.DefaultValueInterface}}
-			m.Dialector.BindVarTo(defaultStmt, defaultStmt, field.DefaultValueInterface)
-			expr.SQL += " DEFAULT " + m.Dialector.Explain(defaultStmt.SQL.String(), field.DefaultValueInterface)
-		} else if field.DefaultValue != "(-)" {
-			expr.SQL += " DEFAULT " + field.DefaultValue
-		}
+	if defaultValue, ok := m.defaultValueOf(field); ok {
+		expr.SQL += " DEFAULT " + defaultValue
 	}
 
 	if field.NotNull {
 		expr.SQL += " NOT NULL"
 	}
 
+	if d := m.Dialector.(Dialector); d.isJSONField(field) && !d.capabilities().NativeJSON {
+		expr.SQL += " CHECK (" + d.quoteName(field.DBName) + " IS JSON)"
+	}
+
 	// see https://github.com/go-gorm/gorm/pull/6822
 	//if field.Unique {
 	//	expr.SQL += " UNIQUE"
@@ -119,6 +121,17 @@ func (m Migrator) CreateTable(values ...interface{}) (err error) {
 			_ = m.TryQuotifyReservedWords(value)
 		}
 		_ = m.TryRemoveOnUpdate(value)
+		if err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			if stmt.Schema == nil {
+				return nil
+			}
+			if err := m.Dialector.(Dialector).checkVectorFields(stmt.Schema.Fields...); err != nil {
+				return err
+			}
+			return m.createSequences(stmt.Schema.Fields...)
+		}); err != nil {
+			return
+		}
 	}
 	if err = m.Migrator.CreateTable(values...); err != nil {
 		return
@@ -127,6 +140,9 @@ func (m Migrator) CreateTable(values ...interface{}) (err error) {
 	for _, value := range m.ReorderModels(values, false) {
 		if err = m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
 			if stmt.Schema != nil {
+				if err = m.createSequenceTriggers(stmt, stmt.Schema.Fields...); err != nil {
+					return
+				}
 				for _, fieldName := range stmt.Schema.DBNames {
 					field := stmt.Schema.FieldsByDBName[fieldName]
 					if err = m.setCommentForColumn(field, stmt); err != nil {
@@ -163,7 +179,14 @@ func (m Migrator) DropTable(values ...interface{}) error {
 		tx := m.DB.Session(&gorm.Session{})
 		if m.HasTable(value) {
 			if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
-				return tx.Exec("DROP TABLE ? CASCADE CONSTRAINTS", clause.Table{Name: stmt.Table}).Error
+				dropSQL := "DROP TABLE ? CASCADE CONSTRAINTS"
+				if m.Dialector.(Dialector).capabilities().IfExists {
+					dropSQL = "DROP TABLE IF EXISTS ? CASCADE CONSTRAINTS"
+				}
+				if err := tx.Exec(dropSQL, clause.Table{Name: stmt.Table}).Error; err != nil || stmt.Schema == nil {
+					return err
+				}
+				return m.dropSequences(stmt.Schema.Fields...)
 			}); err != nil {
 				return err
 			}
@@ -195,52 +218,230 @@ func (m Migrator) getSchemaTable(stmt *gorm.Statement) (ownerName, tableName str
 		tableName = stmt.Table
 	} else {
 		tableName = stmt.Schema.Table
-		if strings.Contains(tableName, ".") {
-			ownerTable := strings.Split(tableName, ".")
-			ownerName, tableName = ownerTable[0], ownerTable[1]
-		}
+	}
+	if strings.Contains(tableName, ".") {
+		ownerTable := strings.Split(tableName, ".")
+		ownerName, tableName = ownerTable[0], ownerTable[1]
 	}
 	return
 }
 
 // ColumnTypes return columnTypes []gorm.ColumnType and execErr error
+//
+// The column types are read from ALL_TAB_COLUMNS, ALL_COL_COMMENTS and ALL_CONSTRAINTS of the table owner,
+// which is the current schema unless the table name is qualified with owner
+//
+//goland:noinspection SqlNoDataSourceInspection
 func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 	columnTypes := make([]gorm.ColumnType, 0)
 	execErr := m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
-		_, tableName := m.getSchemaTable(stmt)
-		rows, err := m.DB.Session(&gorm.Session{}).Table(tableName).Where("ROWNUM = 1").Rows()
+		ownerName, tableName := m.getSchemaTable(stmt)
+		ownerSQL, ownerVars := m.ownerCondition(ownerName)
+
+		identityColumn := "c.IDENTITY_COLUMN"
+		if !m.Dialector.(Dialector).capabilities().IdentityColumns {
+			identityColumn = "'NO'" // since Oracle 12c
+		}
+		rows, err := m.DB.Session(&gorm.Session{}).Raw(
+			"SELECT c.COLUMN_NAME, c.DATA_TYPE, c.DATA_LENGTH, c.CHAR_LENGTH, c.CHAR_USED, c.DATA_PRECISION, c.DATA_SCALE, c.NULLABLE, c.DATA_DEFAULT, cc.COMMENTS, "+identityColumn+
+				" FROM ALL_TAB_COLUMNS c LEFT JOIN ALL_COL_COMMENTS cc"+
+				" ON cc.OWNER = c.OWNER AND cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = c.COLUMN_NAME"+
+				" WHERE c.OWNER = "+ownerSQL+" AND c.TABLE_NAME = ? ORDER BY c.COLUMN_ID",
+			append(ownerVars, tableName)...,
+		).Rows()
 		if err != nil {
 			return err
 		}
 
 		defer func() {
-			err = rows.Close()
+			if closeErr := rows.Close(); err == nil {
+				err = closeErr
+			}
 		}()
 
-		var rawColumnTypes []*sql.ColumnType
-		rawColumnTypes, err = rows.ColumnTypes()
-		if err != nil {
-			return err
-		}
-
 		ignoreCase := !m.Dialector.(Dialector).NamingCaseSensitive
-		for _, c := range rawColumnTypes {
-			columnType := migrator.ColumnType{SQLColumnType: c}
-			if ignoreCase && IsReservedWord(c.Name()) {
-				columnType.NameValue = sql.NullString{
-					String: strconv.Quote(c.Name()),
-					Valid:  true,
+		columnIndexes := make(map[string]int)
+		for rows.Next() {
+			var (
+				name, dataType, charUsed, nullable, identity sql.NullString
+				dataDefault, comment                         sql.NullString
+				dataLength, charLength, precision, scale     sql.NullInt64
+			)
+			if err = rows.Scan(
+				&name, &dataType, &dataLength, &charLength, &charUsed, &precision, &scale,
+				&nullable, &dataDefault, &comment, &identity,
+			); err != nil {
+				return err
+			}
+
+			columnType := migrator.ColumnType{
+				NameValue:          name,
+				DataTypeValue:      sql.NullString{String: oracleTypeName(dataType.String), Valid: true},
+				ColumnTypeValue:    sql.NullString{String: dataType.String, Valid: true},
+				PrimaryKeyValue:    sql.NullBool{Valid: true},
+				UniqueValue:        sql.NullBool{Valid: true},
+				AutoIncrementValue: sql.NullBool{Bool: identity.String == "YES", Valid: true},
+				NullableValue:      sql.NullBool{Bool: nullable.String != "N", Valid: true},
+				ScanTypeValue:      oracleScanType(dataType.String, scale),
+				CommentValue:       sql.NullString{String: comment.String, Valid: true},
+			}
+			if ignoreCase && IsReservedWord(name.String) {
+				columnType.NameValue.String = strconv.Quote(name.String)
+			}
+
+			switch typeName := columnType.DataTypeValue.String; typeName {
+			case "CHAR", "NCHAR", "VARCHAR2", "NVARCHAR2":
+				columnType.LengthValue = dataLength
+				if charUsed.String == "C" {
+					columnType.LengthValue = charLength
+				}
+				if charUsed.String == "C" && !strings.HasPrefix(typeName, "N") {
+					columnType.ColumnTypeValue.String = fmt.Sprintf("%s(%d CHAR)", typeName, charLength.Int64)
+				} else {
+					columnType.ColumnTypeValue.String = fmt.Sprintf("%s(%d)", typeName, columnType.LengthValue.Int64)
+				}
+			case "RAW":
+				columnType.LengthValue = dataLength
+				columnType.ColumnTypeValue.String = fmt.Sprintf("%s(%d)", typeName, dataLength.Int64)
+			case "NUMBER":
+				columnType.DecimalSizeValue, columnType.ScaleValue = precision, scale
+				if precision.Valid && scale.Valid && scale.Int64 != 0 {
+					columnType.ColumnTypeValue.String = fmt.Sprintf("NUMBER(%d,%d)", precision.Int64, scale.Int64)
+				} else if precision.Valid {
+					columnType.ColumnTypeValue.String = fmt.Sprintf("NUMBER(%d)", precision.Int64)
+				} else if scale.Valid {
+					columnType.ColumnTypeValue.String = "NUMBER(*,0)" // INTEGER
 				}
+			case "FLOAT":
+				columnType.DecimalSizeValue = precision
+				if precision.Valid {
+					columnType.ColumnTypeValue.String = fmt.Sprintf("FLOAT(%d)", precision.Int64)
+				}
+			default:
+			}
+
+			if defaultValue := strings.TrimSpace(dataDefault.String); defaultValue != "" && !strings.EqualFold(defaultValue, "NULL") {
+				if columnType.ScanTypeValue == scanTypeString && len(defaultValue) > 1 &&
+					strings.HasPrefix(defaultValue, "'") && strings.HasSuffix(defaultValue, "'") {
+					defaultValue = strings.ReplaceAll(defaultValue[1:len(defaultValue)-1], "''", "'")
+				}
+				columnType.DefaultValueValue = sql.NullString{String: defaultValue, Valid: true}
 			}
+
+			columnIndexes[name.String] = len(columnTypes)
 			columnTypes = append(columnTypes, columnType)
 		}
+		if err = rows.Err(); err != nil {
+			return err
+		}
+
+		if err = rows.Close(); err != nil {
+			return err
+		}
 
+		// primary key and single column unique constraints
+		rows, err = m.DB.Session(&gorm.Session{}).Raw(
+			"SELECT cc.COLUMN_NAME, c.CONSTRAINT_TYPE,"+
+				" (SELECT COUNT(*) FROM ALL_CONS_COLUMNS x WHERE x.OWNER = c.OWNER AND x.CONSTRAINT_NAME = c.CONSTRAINT_NAME)"+
+				" FROM ALL_CONSTRAINTS c JOIN ALL_CONS_COLUMNS cc"+
+				" ON cc.OWNER = c.OWNER AND cc.CONSTRAINT_NAME = c.CONSTRAINT_NAME AND cc.TABLE_NAME = c.TABLE_NAME"+
+				" WHERE c.OWNER = "+ownerSQL+" AND c.TABLE_NAME = ? AND c.CONSTRAINT_TYPE IN ('P', 'U')",
+			append(ownerVars, tableName)...,
+		).Rows()
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var (
+				columnName, constraintType string
+				columnCount                int
+			)
+			if err = rows.Scan(&columnName, &constraintType, &columnCount); err != nil {
+				return err
+			}
+			idx, ok := columnIndexes[columnName]
+			if !ok {
+				continue
+			}
+			columnType := columnTypes[idx].(migrator.ColumnType)
+			if constraintType == "P" {
+				columnType.PrimaryKeyValue.Bool = true
+			} else if columnCount == 1 {
+				columnType.UniqueValue.Bool = true
+			}
+			columnTypes[idx] = columnType
+		}
+		err = rows.Err()
 		return
 	})
 
 	return columnTypes, execErr
 }
 
+// MigrateColumnUnique migrate column's UNIQUE constraint,
+// the constraints are only dropped or created when they are named by GORM
+func (m Migrator) MigrateColumnUnique(value interface{}, field *schema.Field, columnType gorm.ColumnType) error {
+	unique, ok := columnType.Unique()
+	if !ok || field.PrimaryKey || unique == field.Unique {
+		return nil
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		constraint := m.DB.NamingStrategy.UniqueName(stmt.Table, field.DBName)
+		if hasConstraint := m.HasConstraint(value, constraint); unique && hasConstraint {
+			return m.DropConstraint(value, constraint)
+		} else if !unique && !hasConstraint {
+			return m.CreateConstraint(value, constraint)
+		}
+		return nil
+	})
+}
+
+// ownerCondition returns the expression of the table owner in data dictionary views
+func (m Migrator) ownerCondition(ownerName string) (sql string, vars []interface{}) {
+	if ownerName == "" {
+		return "SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA')", nil
+	}
+	return "?", []interface{}{ownerName}
+}
+
+var (
+	scanTypeString = reflect.TypeOf("")
+	scanTypeInt64  = reflect.TypeOf(int64(0))
+	scanTypeFloat  = reflect.TypeOf(float64(0))
+	scanTypeTime   = reflect.TypeOf(time.Time{})
+	scanTypeBytes  = reflect.TypeOf([]byte{})
+	scanTypeBool   = reflect.TypeOf(false)
+
+	dataTypePrecision = regexp.MustCompile(`\(\d+\)`)
+)
+
+// oracleTypeName returns the data type name without fractional seconds precision,
+// e.g. TIMESTAMP WITH TIME ZONE for TIMESTAMP(6) WITH TIME ZONE
+func oracleTypeName(dataType string) string {
+	return strings.ToUpper(dataTypePrecision.ReplaceAllString(dataType, ""))
+}
+
+// oracleScanType returns the Go type for scanning values of Oracle data type
+func oracleScanType(dataType string, scale sql.NullInt64) reflect.Type {
+	switch typeName := oracleTypeName(dataType); {
+	case typeName == "NUMBER":
+		if scale.Valid && scale.Int64 == 0 {
+			return scanTypeInt64
+		}
+		return scanTypeFloat
+	case typeName == "FLOAT" || typeName == "BINARY_FLOAT" || typeName == "BINARY_DOUBLE":
+		return scanTypeFloat
+	case typeName == "DATE" || strings.HasPrefix(typeName, "TIMESTAMP"):
+		return scanTypeTime
+	case typeName == "BLOB" || typeName == "RAW" || typeName == "LONG RAW":
+		return scanTypeBytes
+	case typeName == "BOOLEAN":
+		return scanTypeBool
+	default:
+		return scanTypeString
+	}
+}
+
 // RenameTable rename table from oldName to newName
 func (m Migrator) RenameTable(oldName, newName interface{}) (err error) {
 	resolveTable := func(name interface{}) (result string, err error) {
@@ -287,12 +488,24 @@ func (m Migrator) GetTables() (tableList []string, err error) {
 
 // AddColumn create "name" column for value
 func (m Migrator) AddColumn(value interface{}, name string) (err error) {
+	if err = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		field := stmt.Schema.LookUpField(name)
+		if err := m.Dialector.(Dialector).checkVectorFields(field); err != nil {
+			return err
+		}
+		return m.createSequences(field)
+	}); err != nil {
+		return
+	}
 	if err = m.Migrator.AddColumn(value, name); err != nil {
 		return err
 	}
 	// set column comment
 	err = m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
 		if field := stmt.Schema.LookUpField(name); field != nil {
+			if err = m.createSequenceTriggers(stmt, field); err != nil {
+				return
+			}
 			if err = m.setCommentForColumn(field, stmt); err != nil {
 				return
 			}
@@ -317,6 +530,9 @@ func (m Migrator) AlterColumn(value interface{}, field string) error {
 
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
 		if field := stmt.Schema.LookUpField(field); field != nil {
+			if err := m.Dialector.(Dialector).checkVectorFields(field); err != nil {
+				return err
+			}
 			_, tableName := m.getSchemaTable(stmt)
 			return m.DB.Exec(
 				"ALTER TABLE ? MODIFY ? ?",
@@ -344,35 +560,244 @@ func (m Migrator) HasColumn(value interface{}, field string) bool {
 
 // MigrateColumn migrate column
 func (m Migrator) MigrateColumn(value interface{}, field *schema.Field, columnType gorm.ColumnType) (err error) {
-	if err = m.Migrator.MigrateColumn(value, field, columnType); err != nil {
+	if field.IgnoreMigration {
 		return
 	}
 
-	return m.RunWithValue(value, func(stmt *gorm.Statement) (err error) {
-		var description string
-		if ownerName, tableName := m.getSchemaTable(stmt); ownerName != "" {
-			_ = m.DB.Raw(
-				"SELECT COMMENTS FROM ALL_COL_COMMENTS WHERE OWNER = ? AND TABLE_NAME = ? AND COLUMN_NAME = ?",
-				ownerName, tableName, field.DBName,
-			).Row().Scan(&description)
-		} else {
-			_ = m.DB.Raw(
-				"SELECT COMMENTS FROM USER_COL_COMMENTS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?",
-				tableName, field.DBName,
-			).Row().Scan(&description)
+	typeChanged := !field.PrimaryKey && m.dataTypeChanged(field, columnType)
+	// the NUMBER column isn't converted for the bool field mapped to BOOLEAN, see Config.NativeBoolean
+	numberBool := typeChanged && m.isBooleanConversion(field, columnType)
+	constraintsChanged := false
+
+	// check nullable
+	if nullable, ok := columnType.Nullable(); ok && !field.PrimaryKey && nullable == field.NotNull {
+		constraintsChanged = true
+	}
+
+	// check default value
+	if !field.PrimaryKey && !m.hasGeneratedValue(field) && field.DefaultValue != "(-)" {
+		defaultValue, hasDefault := m.columnDefaultOf(field, numberBool)
+		if hasDefault && strings.EqualFold(defaultValue, "NULL") {
+			hasDefault = false
 		}
-		if comment := field.Comment; comment != "" && comment != description {
-			if err = m.setCommentForColumn(field, stmt); err != nil {
+		if dv, ok := columnType.DefaultValue(); ok != hasDefault || (ok && !sameDefaultValue(field, dv, defaultValue)) {
+			constraintsChanged = true
+		}
+	}
+
+	if numberBool {
+		// Oracle cannot modify the data type of a column with data (ORA-01439), and copying the values
+		// to a new column loses its constraints, indexes and dependent objects, so it's left to the user
+		m.DB.Logger.Warn(m.DB.Statement.Context,
+			"column %s of %s is NUMBER but the bool field is mapped to BOOLEAN, it's not migrated, convert it manually",
+			field.DBName, field.Schema.Table)
+		if constraintsChanged {
+			if err = m.alterColumnConstraints(value, field); err != nil {
 				return
 			}
 		}
+	} else if typeChanged || constraintsChanged {
+		if err = m.AlterColumn(value, field.DBName); err != nil {
+			return
+		}
+	}
+
+	if err = m.MigrateColumnUnique(value, field, columnType); err != nil {
+		return
+	}
+
+	if description, ok := columnType.Comment(); field.Comment == "" || (ok && field.Comment == description) {
 		return
+	}
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		return m.setCommentForColumn(field, stmt)
 	})
 }
 
+// dataTypeChanged reports whether the column type differs from the field's data type
+func (m Migrator) dataTypeChanged(field *schema.Field, columnType gorm.ColumnType) bool {
+	realDataType, ok := columnType.ColumnType()
+	if !ok || realDataType == "" {
+		realDataType = columnType.DatabaseTypeName()
+	}
+	fieldType := m.DataTypeOf(field)
+	byteType := normalizeDataType(fieldType, "")
+	if byteType == normalizeDataType(realDataType, "") {
+		return false
+	} else if byteType == normalizeDataType(fieldType, "CHAR") {
+		return true
+	}
+	// the length of VARCHAR2(n) without BYTE or CHAR is in the NLS_LENGTH_SEMANTICS of the session,
+	// while ColumnTypes writes CHAR for the columns of character length from CHAR_USED
+	semantics := m.lengthSemantics()
+	return semantics == "" || normalizeDataType(fieldType, semantics) != normalizeDataType(realDataType, "")
+}
+
+// lengthSemantics returns the NLS_LENGTH_SEMANTICS of the session, or "" if it's failed to query
+//
+//goland:noinspection SqlNoDataSourceInspection
+func (m Migrator) lengthSemantics() (semantics string) {
+	if err := m.DB.Session(&gorm.Session{NewDB: true}).Raw(
+		"SELECT VALUE FROM NLS_SESSION_PARAMETERS WHERE PARAMETER = 'NLS_LENGTH_SEMANTICS'",
+	).Row().Scan(&semantics); err != nil {
+		return ""
+	}
+	return strings.ToUpper(semantics)
+}
+
+// isBooleanConversion reports whether the NUMBER column is migrated to BOOLEAN, see Config.NativeBoolean
+func (m Migrator) isBooleanConversion(field *schema.Field, columnType gorm.ColumnType) bool {
+	return normalizeDataType(m.DataTypeOf(field), "") == "BOOLEAN" &&
+		strings.EqualFold(columnType.DatabaseTypeName(), "NUMBER")
+}
+
+var (
+	dataTypeClausePattern = regexp.MustCompile(`\s+(GENERATED|DEFAULT|NOT NULL|NULL|UNIQUE|PRIMARY KEY|CHECK)\b.*$`)
+	dataTypePattern       = regexp.MustCompile(`^([A-Z][A-Z0-9_ ]*?)\s*(?:\(([^()]*)\))?((?:\s+[A-Z]+)*)$`)
+)
+
+// normalizeDataType returns the canonical form of Oracle data type for comparison, e.g.
+//
+//	INTEGER, SMALLINT, NUMBER(*,0)         NUMBER(38,0)
+//	NUMBER(1)                              NUMBER(1,0)
+//	VARCHAR2(50), VARCHAR(50)              VARCHAR2(50 BYTE), or VARCHAR2(50 CHAR) if lengthSemantics is CHAR
+//	FLOAT                                  FLOAT(126)
+//	TIMESTAMP WITH TIME ZONE               TIMESTAMP(6) WITH TIME ZONE
+//	VECTOR(768, FLOAT32)                   VECTOR
+//
+// lengthSemantics is the default length semantics of CHAR and VARCHAR2, defaulting to BYTE.
+// The dimension and format of VECTOR aren't in ALL_TAB_COLUMNS, so only the base type is compared.
+func normalizeDataType(sqlType string, lengthSemantics string) string {
+	sqlType = strings.ToUpper(strings.Join(strings.Fields(sqlType), " "))
+	if loc := dataTypeClausePattern.FindStringIndex(sqlType); loc != nil {
+		sqlType = sqlType[:loc[0]]
+	}
+	matches := dataTypePattern.FindStringSubmatch(sqlType)
+	if matches == nil {
+		return sqlType
+	}
+
+	name, suffix := matches[1], matches[3]
+	var args []string
+	for _, arg := range strings.Split(matches[2], ",") {
+		if arg = strings.TrimSpace(arg); arg != "" {
+			args = append(args, arg)
+		}
+	}
+
+	switch name {
+	case "VARCHAR", "VARCHAR2", "CHAR", "CHARACTER":
+		if name == "VARCHAR" {
+			name = "VARCHAR2"
+		} else if name == "CHARACTER" {
+			name = "CHAR"
+		}
+		length, semantics := "1", lengthSemantics
+		if semantics == "" {
+			semantics = "BYTE"
+		}
+		if len(args) > 0 {
+			if parts := strings.Fields(args[0]); len(parts) > 1 {
+				length, semantics = parts[0], parts[1]
+			} else {
+				length = parts[0]
+			}
+		}
+		return fmt.Sprintf("%s(%s %s)", name, length, semantics)
+	case "NCHAR":
+		if len(args) == 0 {
+			return "NCHAR(1)"
+		}
+	case "INTEGER", "INT", "SMALLINT":
+		return "NUMBER(38,0)"
+	case "NUMBER", "DECIMAL", "NUMERIC", "DEC":
+		if len(args) == 0 {
+			if name == "NUMBER" {
+				return name
+			}
+			return "NUMBER(38,0)"
+		}
+		precision, scale := args[0], "0"
+		if precision == "*" {
+			precision = "38"
+		}
+		if len(args) > 1 {
+			scale = args[1]
+		}
+		return fmt.Sprintf("NUMBER(%s,%s)", precision, scale)
+	case "FLOAT":
+		if len(args) == 0 {
+			return "FLOAT(126)"
+		}
+	case "DOUBLE":
+		if suffix == " PRECISION" {
+			return "FLOAT(126)"
+		}
+	case "REAL":
+		return "FLOAT(63)"
+	case "VECTOR":
+		return name
+	case "TIMESTAMP":
+		if len(args) == 0 {
+			args = []string{"6"}
+		}
+	default:
+	}
+
+	if len(args) > 0 {
+		return name + "(" + strings.Join(args, ",") + ")" + suffix
+	}
+	return name + suffix
+}
+
+// sameDefaultValue reports whether the column default value equals to the field's default value
+func sameDefaultValue(field *schema.Field, columnDefault, fieldDefault string) bool {
+	normalize := func(v string) string {
+		v = strings.TrimSuffix(strings.TrimSpace(v), "()")
+		if len(v) > 1 && strings.HasPrefix(v, "'") && strings.HasSuffix(v, "'") {
+			v = strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+		}
+		return v
+	}
+	columnDefault, fieldDefault = normalize(columnDefault), normalize(fieldDefault)
+	if columnDefault == fieldDefault {
+		return true
+	} else if field.GORMDataType == schema.String {
+		return false
+	} else if strings.EqualFold(columnDefault, fieldDefault) {
+		return true
+	}
+	v1, err1 := strconv.ParseFloat(columnDefault, 64)
+	v2, err2 := strconv.ParseFloat(fieldDefault, 64)
+	return err1 == nil && err2 == nil && v1 == v2
+}
+
 func (m Migrator) AlterDataTypeOf(stmt *gorm.Statement, field *schema.Field) (expr clause.Expr) {
-	expr.SQL = m.DataTypeOf(field)
+	expr.SQL = m.DataTypeOf(field) + m.alterConstraintsOf(stmt, field, false)
+	return
+}
 
+// alterColumnConstraints modifies the DEFAULT and NULL constraints of the NUMBER column of the bool field
+// without its data type, see isBooleanConversion
+func (m Migrator) alterColumnConstraints(value interface{}, field *schema.Field) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		constraints := strings.TrimSpace(m.alterConstraintsOf(stmt, field, true))
+		if constraints == "" {
+			return nil
+		}
+		_, tableName := m.getSchemaTable(stmt)
+		return m.DB.Exec(
+			"ALTER TABLE ? MODIFY ? ?",
+			clause.Table{Name: tableName},
+			clause.Column{Name: field.DBName},
+			clause.Expr{SQL: constraints},
+		).Error
+	})
+}
+
+// alterConstraintsOf returns the DEFAULT and NULL clauses of field to modify its column,
+// the default value of bool field is written as 1 or 0 if numberBool
+func (m Migrator) alterConstraintsOf(stmt *gorm.Statement, field *schema.Field, numberBool bool) (sql string) {
 	var nullable = ""
 	if ownerName, tableName := m.getSchemaTable(stmt); ownerName != "" {
 		_ = m.DB.Raw("SELECT NULLABLE FROM ALL_TAB_COLUMNS WHERE OWNER = ? and TABLE_NAME = ? AND COLUMN_NAME = ?", ownerName, tableName, field.DBName).Row().Scan(&nullable)
@@ -380,25 +805,49 @@ func (m Migrator) AlterDataTypeOf(stmt *gorm.Statement, field *schema.Field) (ex
 		_ = m.DB.Raw("SELECT NULLABLE FROM USER_TAB_COLUMNS WHERE TABLE_NAME = ? AND COLUMN_NAME = ?", tableName, field.DBName).Row().Scan(&nullable)
 	}
 
-	if field.HasDefaultValue && (field.DefaultValueInterface != nil || field.DefaultValue != "") {
-		if field.DefaultValueInterface != nil {
-			defaultStmt := &gorm.Statement{Vars: []interface{}{field.DefaultValueInterface}}
-			m.Dialector.BindVarTo(defaultStmt, defaultStmt, field.DefaultValueInterface)
-			expr.SQL += " DEFAULT " + m.Dialector.Explain(defaultStmt.SQL.String(), field.DefaultValueInterface)
-		} else if field.DefaultValue != "(-)" {
-			expr.SQL += " DEFAULT " + field.DefaultValue
-		}
+	if defaultValue, ok := m.columnDefaultOf(field, numberBool); ok {
+		sql += " DEFAULT " + defaultValue
+	} else if !m.hasGeneratedValue(field) && field.DefaultValue != "(-)" {
+		sql += " DEFAULT NULL"
 	}
 
 	if field.NotNull && nullable == "Y" {
-		expr.SQL += " NOT NULL"
-	}
-	if field.Unique {
-		expr.SQL += " UNIQUE"
+		sql += " NOT NULL"
+	} else if !field.NotNull && !field.PrimaryKey && nullable == "N" {
+		sql += " NULL"
 	}
+	// UNIQUE constraint is migrated by MigrateColumnUnique
 	return
 }
 
+// columnDefaultOf returns the DEFAULT expression of field like defaultValueOf,
+// the default value of bool field is 1 or 0 for its NUMBER column if numberBool
+func (m Migrator) columnDefaultOf(field *schema.Field, numberBool bool) (defaultValue string, ok bool) {
+	if v, isBool := field.DefaultValueInterface.(bool); numberBool && isBool && !m.hasGeneratedValue(field) {
+		return fmt.Sprint(convertBool(v, false)), true
+	}
+	return m.defaultValueOf(field)
+}
+
+// defaultValueOf returns the DEFAULT expression of field in DDL
+func (m Migrator) defaultValueOf(field *schema.Field) (defaultValue string, ok bool) {
+	if m.hasGeneratedValue(field) || !field.HasDefaultValue {
+		return
+	}
+	if field.DefaultValueInterface != nil {
+		defaultStmt := &gorm.Statement{Vars: []interface{}{field.DefaultValueInterface}}
+		m.Dialector.BindVarTo(defaultStmt, defaultStmt, field.DefaultValueInterface)
+		return m.Dialector.Explain(defaultStmt.SQL.String(), field.DefaultValueInterface), true
+	}
+	return field.DefaultValue, field.DefaultValue != "" && field.DefaultValue != "(-)"
+}
+
+// hasGeneratedValue reports whether the column values are generated by identity or sequence
+func (m Migrator) hasGeneratedValue(field *schema.Field) bool {
+	_, withSequence := m.Dialector.(Dialector).SequenceOf(field)
+	return withSequence || field.AutoIncrement
+}
+
 // CreateConstraint create constraint
 func (m Migrator) CreateConstraint(value interface{}, name string) error {
 	_ = m.TryRemoveOnUpdate(value)
@@ -437,6 +886,51 @@ func (m Migrator) HasConstraint(value interface{}, name string) bool {
 	}) == nil && count > 0
 }
 
+// vectorIndexOrganizations are the ORGANIZATION clauses of the vector index types
+var vectorIndexOrganizations = map[string]string{
+	"HNSW": "ORGANIZATION INMEMORY NEIGHBOR GRAPH",
+	"IVF":  "ORGANIZATION NEIGHBOR PARTITIONS",
+}
+
+// CreateIndex create index "name", the class of index is UNIQUE, BITMAP or VECTOR,
+// the type of VECTOR index is HNSW or IVF, and the option is appended to the statement, e.g.
+//
+//	Embedding oracle.Vector[float32] `gorm:"size:768;index:idx_doc_embedding,class:VECTOR,type:HNSW,option:DISTANCE COSINE WITH TARGET ACCURACY 95"`
+//	// CREATE VECTOR INDEX "idx_doc_embedding" ON "documents" ("embedding") ORGANIZATION INMEMORY NEIGHBOR GRAPH DISTANCE COSINE WITH TARGET ACCURACY 95
+func (m Migrator) CreateIndex(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema == nil {
+			return errors.New("failed to get schema")
+		}
+		idx := stmt.Schema.LookIndex(name)
+		if idx == nil {
+			return fmt.Errorf("failed to create index with name %s", name)
+		}
+
+		if strings.EqualFold(idx.Class, "VECTOR") && !m.Dialector.(Dialector).capabilities().Vector {
+			return fmt.Errorf("%w: index %s", ErrVectorUnsupported, idx.Name)
+		}
+
+		opts := m.BuildIndexOptions(idx.Fields, stmt)
+		values := []interface{}{clause.Column{Name: idx.Name}, m.CurrentTable(stmt), opts}
+
+		createIndexSQL := "CREATE "
+		if idx.Class != "" {
+			createIndexSQL += idx.Class + " "
+		}
+		createIndexSQL += "INDEX ? ON ??"
+
+		// Oracle 不支持 USING 和 COMMENT，仅向量索引的类型转换为 ORGANIZATION 子句
+		if organization, ok := vectorIndexOrganizations[strings.ToUpper(idx.Type)]; ok && strings.EqualFold(idx.Class, "VECTOR") {
+			createIndexSQL += " " + organization
+		}
+		if idx.Option != "" {
+			createIndexSQL += " " + idx.Option
+		}
+		return m.DB.Exec(createIndexSQL, values...).Error
+	})
+}
+
 // DropIndex drop index "name"
 func (m Migrator) DropIndex(value interface{}, name string) error {
 	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
@@ -480,6 +974,83 @@ func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error
 	})
 }
 
+// Index implements gorm.Index interface with the Oracle index type
+type Index struct {
+	migrator.Index
+	// TypeValue is the INDEX_TYPE of ALL_INDEXES, e.g. NORMAL, BITMAP, FUNCTION-BASED NORMAL
+	TypeValue string
+}
+
+// Type return the type of the index, e.g. NORMAL, BITMAP, FUNCTION-BASED NORMAL
+func (idx Index) Type() string {
+	return idx.TypeValue
+}
+
+// GetIndexes return Indexes []gorm.Index and execErr error,
+// the columns of function-based indexes are their expressions
+//
+//goland:noinspection SqlNoDataSourceInspection
+func (m Migrator) GetIndexes(value interface{}) ([]gorm.Index, error) {
+	indexes := make([]gorm.Index, 0)
+	execErr := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		ownerName, tableName := m.getSchemaTable(stmt)
+		ownerSQL, ownerVars := m.ownerCondition(ownerName)
+
+		rows, err := m.DB.Session(&gorm.Session{}).Raw(
+			"SELECT i.INDEX_NAME, i.INDEX_TYPE, i.UNIQUENESS, ic.COLUMN_NAME, ie.COLUMN_EXPRESSION,"+
+				" (SELECT COUNT(*) FROM ALL_CONSTRAINTS c WHERE c.OWNER = i.TABLE_OWNER AND c.TABLE_NAME = i.TABLE_NAME"+
+				" AND c.CONSTRAINT_TYPE = 'P' AND c.INDEX_NAME = i.INDEX_NAME)"+
+				" FROM ALL_INDEXES i JOIN ALL_IND_COLUMNS ic ON ic.INDEX_OWNER = i.OWNER AND ic.INDEX_NAME = i.INDEX_NAME"+
+				" LEFT JOIN ALL_IND_EXPRESSIONS ie ON ie.INDEX_OWNER = ic.INDEX_OWNER AND ie.INDEX_NAME = ic.INDEX_NAME"+
+				" AND ie.COLUMN_POSITION = ic.COLUMN_POSITION"+
+				" WHERE i.TABLE_OWNER = "+ownerSQL+" AND i.TABLE_NAME = ? AND i.INDEX_TYPE <> 'LOB'"+
+				" ORDER BY i.INDEX_NAME, ic.COLUMN_POSITION",
+			append(ownerVars, tableName)...,
+		).Rows()
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = rows.Close()
+		}()
+
+		indexMap := make(map[string]int)
+		for rows.Next() {
+			var (
+				indexName, indexType, uniqueness, column string
+				expression                               sql.NullString
+				primaryKey                               int
+			)
+			if err = rows.Scan(&indexName, &indexType, &uniqueness, &column, &expression, &primaryKey); err != nil {
+				return err
+			}
+			if expr := strings.TrimSpace(expression.String); expr != "" {
+				column = expr
+			}
+			if idx, ok := indexMap[indexName]; ok {
+				index := indexes[idx].(Index)
+				index.ColumnList = append(index.ColumnList, column)
+				indexes[idx] = index
+				continue
+			}
+			indexMap[indexName] = len(indexes)
+			indexes = append(indexes, Index{
+				Index: migrator.Index{
+					TableName:       tableName,
+					NameValue:       indexName,
+					ColumnList:      []string{column},
+					PrimaryKeyValue: sql.NullBool{Bool: primaryKey > 0, Valid: true},
+					UniqueValue:     sql.NullBool{Bool: uniqueness == "UNIQUE", Valid: true},
+				},
+				TypeValue: indexType,
+			})
+		}
+		return rows.Err()
+	})
+
+	return indexes, execErr
+}
+
 func (m Migrator) TryRemoveOnUpdate(values ...interface{}) error {
 	for _, value := range values {
 		if err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
@@ -1,12 +1,18 @@
 package oracle
 
 import (
+	"context"
+	"database/sql/driver"
 	"encoding/json"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/godoes/gorm-oracle/oracletest"
 )
 
 func TestMigrator_AutoMigrate(t *testing.T) {
@@ -15,8 +21,7 @@ func TestMigrator_AutoMigrate(t *testing.T) {
 		t.Fatal(err)
 	}
 	if db == nil {
-		t.Log("db is nil!")
-		return
+		t.Skip("db is nil!")
 	}
 
 	type args struct {
@@ -194,8 +199,7 @@ func TestMigrator_TableColumnType(t *testing.T) {
 		t.Fatal(err)
 	}
 	if db == nil {
-		t.Log("db is nil!")
-		return
+		t.Skip("db is nil!")
 	}
 	testModel := new(testTableColumnTypeModel)
 
@@ -246,15 +250,13 @@ func TestMigrator_FieldNameIsReservedWord(t *testing.T) {
 		t.Fatal(err)
 	}
 	if dbNamingCase == nil {
-		t.Log("dbNamingCase is nil!")
-		return
+		t.Skip("dbNamingCase is nil!")
 	}
 	if err := dbErrors[1]; err != nil {
 		t.Fatal(err)
 	}
 	if dbIgnoreCase == nil {
-		t.Log("dbNamingCase is nil!")
-		return
+		t.Skip("dbNamingCase is nil!")
 	}
 
 	testModel := new(testFieldNameIsReservedWord)
@@ -293,8 +295,7 @@ func TestMigrator_DatatypesJsonMapNamingCase(t *testing.T) {
 		t.Fatal(err)
 	}
 	if dbNamingCase == nil {
-		t.Log("dbNamingCase is nil!")
-		return
+		t.Skip("dbNamingCase is nil!")
 	}
 
 	type testJsonMapNamingCase struct {
@@ -335,8 +336,7 @@ func TestMigrator_DatatypesJsonMapIgnoreCase(t *testing.T) {
 		t.Fatal(err)
 	}
 	if dbIgnoreCase == nil {
-		t.Log("dbNamingCase is nil!")
-		return
+		t.Skip("dbNamingCase is nil!")
 	}
 
 	type tesJsonMapIgnoreCase struct {
@@ -371,3 +371,310 @@ func TestMigrator_DatatypesJsonMapIgnoreCase(t *testing.T) {
 		})
 	}
 }
+
+func TestMigrator_Sequence(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	type testSequence struct {
+		ID   uint64 `gorm:"primaryKey;sequence:test_sequence_id_seq"`
+		Code int64  `gorm:"sequence:test_sequence_code_seq;default:null"`
+		Name string `gorm:"size:50"`
+	}
+	model := new(testSequence)
+	migrator := db.Migrator()
+	_ = migrator.DropTable(model)
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	// the second migration must not fail on the existing sequences
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	defer func() {
+		_ = migrator.DropTable(model)
+		_ = db.Exec(`DROP SEQUENCE "test_sequence_id_seq"`).Error
+		_ = db.Exec(`DROP SEQUENCE "test_sequence_code_seq"`).Error
+	}()
+
+	data := []testSequence{{Name: "Lisa"}, {Name: "Daniela"}}
+	for i := range data {
+		if err = db.Create(&data[i]).Error; err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if data[0].ID == 0 || data[1].ID <= data[0].ID {
+		t.Errorf("unexpected sequence ids: %d, %d", data[0].ID, data[1].ID)
+	}
+	if data[0].Code == 0 || data[1].Code <= data[0].Code {
+		t.Errorf("unexpected sequence codes: %d, %d", data[0].Code, data[1].Code)
+	}
+}
+
+func TestMigrator_ColumnTypes(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	model := TestTableUserUnique{}
+	migrator := db.Migrator()
+	_ = migrator.DropTable(model)
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	defer func() { _ = migrator.DropTable(model) }()
+
+	columnTypes, err := migrator.ColumnTypes(model)
+	if err != nil {
+		t.Fatalf("ColumnTypes() error = %v", err)
+	}
+	columns := make(map[string]gorm.ColumnType, len(columnTypes))
+	for _, columnType := range columnTypes {
+		columns[columnType.Name()] = columnType
+	}
+
+	if primaryKey, _ := columns["id"].PrimaryKey(); !primaryKey {
+		t.Errorf("column id should be primary key")
+	}
+	if unique, _ := columns["uid"].Unique(); !unique {
+		t.Errorf("column uid should be unique")
+	}
+	if length, ok := columns["name"].Length(); !ok || length != 50 {
+		t.Errorf("length of column name = %d, want 50", length)
+	}
+	if comment, _ := columns["name"].Comment(); comment != "用户姓名" {
+		t.Errorf("comment of column name = %s, want 用户姓名", comment)
+	}
+	if nullable, _ := columns["id"].Nullable(); nullable {
+		t.Errorf("column id should not be nullable")
+	}
+	if precision, _, ok := columns["enabled"].DecimalSize(); !ok || precision != 1 {
+		t.Errorf("precision of column enabled = %d, want 1", precision)
+	}
+	if columnType, _ := columns["birthday"].ColumnType(); !strings.HasPrefix(columnType, "TIMESTAMP") {
+		t.Errorf("type of column birthday = %s, want TIMESTAMP", columnType)
+	}
+}
+
+func TestMigrator_GetIndexes(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	type testIndexes struct {
+		ID    uint64 `gorm:"primaryKey"`
+		Code  string `gorm:"size:50;uniqueIndex:idx_test_indexes_code"`
+		Name  string `gorm:"size:50;index:idx_test_indexes_name_age,priority:1"`
+		Age   int    `gorm:"index:idx_test_indexes_name_age,priority:2"`
+		Email string `gorm:"size:128"`
+	}
+	model := new(testIndexes)
+	migrator := db.Migrator()
+	_ = migrator.DropTable(model)
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	defer func() { _ = migrator.DropTable(model) }()
+	if err = db.Exec(`CREATE INDEX "idx_test_indexes_email" ON "test_indexes" (UPPER("email"))`).Error; err != nil {
+		t.Fatalf("create function-based index error = %v", err)
+	}
+
+	indexes, err := migrator.GetIndexes(model)
+	if err != nil {
+		t.Fatalf("GetIndexes() error = %v", err)
+	}
+	indexMap := make(map[string]Index, len(indexes))
+	for _, index := range indexes {
+		indexMap[index.Name()] = index.(Index)
+	}
+
+	if index, ok := indexMap["idx_test_indexes_code"]; !ok {
+		t.Errorf("index idx_test_indexes_code not found in %v", indexes)
+	} else if unique, _ := index.Unique(); !unique {
+		t.Errorf("index idx_test_indexes_code should be unique")
+	}
+	if index, ok := indexMap["idx_test_indexes_name_age"]; !ok {
+		t.Errorf("index idx_test_indexes_name_age not found in %v", indexes)
+	} else if !reflect.DeepEqual(index.Columns(), []string{"name", "age"}) {
+		t.Errorf("columns of index idx_test_indexes_name_age = %v, want [name age]", index.Columns())
+	}
+	if index, ok := indexMap["idx_test_indexes_email"]; !ok {
+		t.Errorf("index idx_test_indexes_email not found in %v", indexes)
+	} else if !strings.HasPrefix(index.Type(), "FUNCTION-BASED") {
+		t.Errorf("type of index idx_test_indexes_email = %s, want FUNCTION-BASED", index.Type())
+	}
+
+	var hasPrimaryKey bool
+	for _, index := range indexes {
+		if primaryKey, _ := index.PrimaryKey(); primaryKey {
+			hasPrimaryKey = reflect.DeepEqual(index.Columns(), []string{"id"})
+		}
+	}
+	if !hasPrimaryKey {
+		t.Errorf("primary key index not found in %v", indexes)
+	}
+}
+
+func TestNormalizeDataType(t *testing.T) {
+	tests := []struct {
+		fieldType  string
+		columnType string
+	}{
+		{fieldType: "NUMBER(1)", columnType: "NUMBER(1)"},
+		{fieldType: "INTEGER", columnType: "NUMBER(*,0)"},
+		{fieldType: "SMALLINT", columnType: "NUMBER(38)"},
+		{fieldType: "INTEGER GENERATED BY DEFAULT AS IDENTITY", columnType: "NUMBER(*,0)"},
+		{fieldType: `INTEGER DEFAULT "SEQ".NEXTVAL`, columnType: "NUMBER(*,0)"},
+		{fieldType: "decimal(10,2)", columnType: "NUMBER(10,2)"},
+		{fieldType: "FLOAT", columnType: "FLOAT(126)"},
+		{fieldType: "VARCHAR2(50)", columnType: "VARCHAR2(50)"},
+		{fieldType: "varchar(50)", columnType: "VARCHAR2(50)"},
+		{fieldType: "VARCHAR2(50 CHAR)", columnType: "VARCHAR2(50 CHAR)"},
+		{fieldType: "char(1)", columnType: "CHAR(1)"},
+		{fieldType: "TIMESTAMP WITH TIME ZONE", columnType: "TIMESTAMP(6) WITH TIME ZONE"},
+		{fieldType: "CLOB", columnType: "CLOB"},
+		{fieldType: "VECTOR(768, FLOAT32)", columnType: "VECTOR"},
+		{fieldType: "VECTOR(*, *)", columnType: "VECTOR"},
+	}
+	for _, tt := range tests {
+		if got, want := normalizeDataType(tt.fieldType, ""), normalizeDataType(tt.columnType, ""); got != want {
+			t.Errorf("normalizeDataType(%q) = %q, want %q", tt.fieldType, got, want)
+		}
+	}
+
+	changes := []struct {
+		fieldType  string
+		columnType string
+	}{
+		{fieldType: "VARCHAR2(50 CHAR)", columnType: "VARCHAR2(50)"},
+		{fieldType: "VARCHAR2(100)", columnType: "VARCHAR2(50)"},
+		{fieldType: "NUMBER(1)", columnType: "NUMBER(*,0)"},
+		{fieldType: "TIMESTAMP WITH TIME ZONE", columnType: "TIMESTAMP(6)"},
+		{fieldType: "CLOB", columnType: "VARCHAR2(4000)"},
+	}
+	for _, tt := range changes {
+		if normalizeDataType(tt.fieldType, "") == normalizeDataType(tt.columnType, "") {
+			t.Errorf("normalizeDataType(%q) should differ from %q", tt.fieldType, tt.columnType)
+		}
+	}
+
+	// NLS_LENGTH_SEMANTICS = CHAR
+	if got, want := normalizeDataType("VARCHAR2(50)", "CHAR"), normalizeDataType("VARCHAR2(50 CHAR)", ""); got != want {
+		t.Errorf("normalizeDataType(%q) of CHAR semantics = %q, want %q", "VARCHAR2(50)", got, want)
+	}
+	if got := normalizeDataType("VARCHAR2(50 BYTE)", "CHAR"); got != "VARCHAR2(50 BYTE)" {
+		t.Errorf("normalizeDataType(%q) of CHAR semantics = %q, want explicit BYTE", "VARCHAR2(50 BYTE)", got)
+	}
+}
+
+type testLengthSemantics struct {
+	ID   uint64 `gorm:"primaryKey"`
+	Name string `gorm:"size:200"`
+}
+
+func TestMigrator_AutoMigrateCharSemantics(t *testing.T) {
+	for _, tt := range []struct {
+		semantics  string
+		charUsed   string
+		dataLength int64
+		wantAlter  bool
+	}{
+		{semantics: "CHAR", charUsed: "C", dataLength: 800},
+		{semantics: "BYTE", charUsed: "B", dataLength: 200},
+		{semantics: "BYTE", charUsed: "C", dataLength: 800, wantAlter: true},
+	} {
+		t.Run(tt.semantics+"_"+tt.charUsed, func(t *testing.T) {
+			db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+			// the table created by the first AutoMigrate in a session of the semantics
+			recorder.Script(`FROM USER_TABLES`, oracletest.Result{Columns: []string{"COUNT"}, Rows: [][]driver.Value{{int64(1)}}})
+			recorder.Script(`FROM USER_TAB_COLUMNS`, oracletest.Result{Columns: []string{"COUNT"}, Rows: [][]driver.Value{{int64(1)}}})
+			recorder.Script(`FROM NLS_SESSION_PARAMETERS`, oracletest.Result{Columns: []string{"VALUE"}, Rows: [][]driver.Value{{tt.semantics}}})
+			recorder.Script(`FROM ALL_TAB_COLUMNS`, oracletest.Result{
+				Columns: []string{"COLUMN_NAME", "DATA_TYPE", "DATA_LENGTH", "CHAR_LENGTH", "CHAR_USED",
+					"DATA_PRECISION", "DATA_SCALE", "NULLABLE", "DATA_DEFAULT", "COMMENTS", "IDENTITY_COLUMN"},
+				Rows: [][]driver.Value{
+					{"id", "NUMBER", int64(22), int64(0), nil, nil, int64(0), "N", nil, nil, "NO"},
+					{"name", "VARCHAR2", tt.dataLength, int64(200), tt.charUsed, nil, nil, "Y", nil, nil, "NO"},
+				},
+			})
+			recorder.Script(`FROM ALL_CONSTRAINTS`, oracletest.Result{
+				Columns: []string{"COLUMN_NAME", "CONSTRAINT_TYPE", "COUNT"},
+				Rows:    [][]driver.Value{{"id", "P", int64(1)}},
+			})
+
+			if err := db.Migrator().AutoMigrate(&testLengthSemantics{}); err != nil {
+				t.Fatal(err)
+			}
+			var altered bool
+			for _, stmt := range recorder.Statements() {
+				if !stmt.Query && strings.HasPrefix(stmt.SQL, "ALTER") {
+					altered = true
+				}
+			}
+			if altered != tt.wantAlter {
+				t.Errorf("AutoMigrate() altered = %v, want %v: %v", altered, tt.wantAlter, recorder.Statements())
+			}
+		})
+	}
+}
+
+type migrateRecorder struct {
+	logger.Interface
+	statements []string
+}
+
+func (r *migrateRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.statements = append(r.statements, sql)
+}
+
+func TestMigrator_AutoMigrateIdempotent(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	type testIdempotent struct {
+		ID        uint64    `gorm:"primaryKey;comment:ID"`
+		Code      string    `gorm:"size:50;not null;unique"`
+		Name      string    `gorm:"size:50;default:'Nobody'"`
+		Enabled   bool      `gorm:"default:true"`
+		Amount    float64   `gorm:"type:decimal(10,2)"`
+		Count     int       `gorm:"size:8;default:0"`
+		Remark    string    `gorm:"type:varchar(100);comment:备注"`
+		CreatedAt time.Time `gorm:"default:SYSTIMESTAMP"`
+	}
+	model := new(testIdempotent)
+	migrator := db.Migrator()
+	_ = migrator.DropTable(model)
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	defer func() { _ = migrator.DropTable(model) }()
+
+	recorder := &migrateRecorder{Interface: db.Logger}
+	if err = db.Session(&gorm.Session{Logger: recorder}).AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+	for _, statement := range recorder.statements {
+		if upper := strings.ToUpper(statement); strings.HasPrefix(upper, "ALTER") || strings.HasPrefix(upper, "COMMENT") {
+			t.Errorf("the second AutoMigrate should not change table: %s", statement)
+		}
+	}
+}
@@ -0,0 +1,359 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// ObjectDataType is the gorm data type of Object fields, which are mapped to the object type of the oracle tag
+	ObjectDataType schema.DataType = "object"
+	// CollectionDataType is the gorm data type of Collection fields, which are mapped to the VARRAY
+	// or nested table type of the oracle tag
+	CollectionDataType schema.DataType = "collection"
+)
+
+// Object is a value of the Oracle object type, T is a struct with the udt tags of go-ora on its fields,
+// the name of the type is set by the oracle tag of the field, see RegisterTypes
+//
+//	type Address struct {
+//		Street string `udt:"STREET"`
+//		City   string `udt:"CITY"`
+//	}
+//
+//	type Customer struct {
+//		ID        uint64
+//		Address   oracle.Object[Address]     `oracle:"type:ADDRESS_T"`
+//		Addresses oracle.Collection[Address] `oracle:"type:ADDRESS_LIST_T"`
+//		Phones    oracle.Collection[string]  `oracle:"type:PHONE_LIST_T"`
+//	}
+type Object[T any] struct {
+	V T
+	// Valid is true if the object is not NULL
+	Valid bool
+}
+
+// Collection is a value of the Oracle VARRAY or nested table type, T is a scalar type or a struct of object type,
+// the name of the type is set by the oracle tag of the field, see Object
+type Collection[T any] []T
+
+// objectTypeValuer is implemented by Object and Collection
+type objectTypeValuer interface {
+	// oracleValue returns the value bound by go-ora, nil for NULL
+	oracleValue() interface{}
+	// elemType returns the type of the object, or the type of the elements of collection
+	elemType() reflect.Type
+	isCollection() bool
+}
+
+// objectTypeNames are the names of the object types registered by RegisterType, keyed by the struct type
+var objectTypeNames sync.Map
+
+// NewObject returns the valid Object of the value
+func NewObject[T any](v T) Object[T] {
+	return Object[T]{V: v, Valid: true}
+}
+
+// Scan value into Object, implements sql.Scanner interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (o *Object[T]) Scan(val interface{}) error {
+	switch v := val.(type) {
+	case nil:
+		*o = Object[T]{}
+	case T:
+		*o = Object[T]{V: v, Valid: true}
+	case *T:
+		if v == nil {
+			*o = Object[T]{}
+		} else {
+			*o = Object[T]{V: *v, Valid: true}
+		}
+	default:
+		return errors.New(fmt.Sprint("Failed to scan object value:", val))
+	}
+	return nil
+}
+
+// GormDataType gorm common data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (Object[T]) GormDataType() string {
+	return string(ObjectDataType)
+}
+
+// GormValue binds the struct registered to go-ora, use Typed to bind it with the name of type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (o Object[T]) GormValue(context.Context, *gorm.DB) clause.Expr {
+	return clause.Expr{SQL: "?", Vars: []interface{}{singleVar{value: o.oracleValue()}}}
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (o Object[T]) oracleValue() interface{} {
+	if !o.Valid {
+		return nil
+	}
+	return o.V
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (Object[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (Object[T]) isCollection() bool {
+	return false
+}
+
+// Scan value into Collection, implements sql.Scanner interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (c *Collection[T]) Scan(val interface{}) error {
+	switch v := val.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []T:
+		*c = append(Collection[T]{}, v...)
+		return nil
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return errors.New(fmt.Sprint("Failed to scan collection value:", val))
+	}
+	result := make(Collection[T], rv.Len())
+	for idx := range result {
+		if err := assignCursorValue(&result[idx], rv.Index(idx).Interface()); err != nil {
+			return fmt.Errorf("failed to scan element %d of collection: %w", idx, err)
+		}
+	}
+	*c = result
+	return nil
+}
+
+// GormDataType gorm common data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (Collection[T]) GormDataType() string {
+	return string(CollectionDataType)
+}
+
+// GormValue binds the slice as one value, the slices of structs are bound as the collection type
+// registered with the struct, use Typed to bind the collections of scalar types
+//
+//goland:noinspection GoMixedReceiverTypes
+func (c Collection[T]) GormValue(context.Context, *gorm.DB) clause.Expr {
+	return clause.Expr{SQL: "?", Vars: []interface{}{singleVar{value: c.oracleValue()}}}
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (c Collection[T]) oracleValue() interface{} {
+	if c == nil {
+		return nil
+	}
+	return []T(c)
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (Collection[T]) elemType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (Collection[T]) isCollection() bool {
+	return true
+}
+
+// singleVar binds the value as one variable, Statement.AddVar would expand the slices into lists
+type singleVar struct {
+	value interface{}
+}
+
+// Build implements clause.Expression
+func (v singleVar) Build(builder clause.Builder) {
+	if stmt, ok := builder.(*gorm.Statement); ok {
+		addArrayVar(stmt, v.value)
+		return
+	}
+	builder.AddVar(builder, v.value)
+}
+
+// Typed returns the bind value of the object or collection type, the value is a struct, a slice,
+// an Object or a Collection, it's used for the binds without the oracle tag, such as Raw and Call
+//
+//	db.Exec("INSERT INTO CUSTOMERS (ID, PHONES) VALUES (?, ?)", id, oracle.Typed("PHONE_LIST_T", []string{"110", "120"}))
+//	oracle.Call(db, "PKG_CUSTOMER.SET_ADDRESS", oracle.InParam(id), oracle.InParam(oracle.Typed("ADDRESS_T", address)))
+func Typed(typeName string, value interface{}) interface{} {
+	if valuer, ok := value.(objectTypeValuer); ok {
+		value = valuer.oracleValue()
+	}
+	return &go_ora.Object{Name: typeName, Value: value}
+}
+
+// objectTypeName returns the name of the object or collection type of the field,
+// which is the type of the oracle tag, or the type of the gorm tag
+func objectTypeName(field *schema.Field) (name string, ok bool) {
+	if field == nil || field.IndirectFieldType == nil {
+		return
+	}
+	if _, ok = reflect.New(field.IndirectFieldType).Interface().(objectTypeValuer); !ok {
+		return
+	}
+	name = schema.ParseTagSetting(field.Tag.Get("oracle"), ";")["TYPE"]
+	if name == "" && field.DataType != ObjectDataType && field.DataType != CollectionDataType {
+		name = string(field.DataType)
+	}
+	return name, name != ""
+}
+
+// objectTypeValue returns the bind value of the object or collection field with the name of its type
+func objectTypeValue(field *schema.Field, value interface{}) interface{} {
+	name, ok := objectTypeName(field)
+	if !ok {
+		return value
+	}
+	switch v := ptrDereference(value).(type) {
+	case objectTypeValuer:
+		return &go_ora.Object{Name: name, Value: v.oracleValue()}
+	case nil, clause.Expression, *gorm.DB, *go_ora.Object:
+		return value
+	default:
+		// the struct or slice assigned to the field, e.g. Update("address", address)
+		return &go_ora.Object{Name: name, Value: v}
+	}
+}
+
+// convertObjectValues replaces the values of object and collection fields with the binds of their types
+func convertObjectValues(stmtSchema *schema.Schema, values clause.Values) {
+	if stmtSchema == nil {
+		return
+	}
+	for idx, column := range values.Columns {
+		if field := stmtSchema.LookUpField(column.Name); field != nil {
+			if _, ok := objectTypeName(field); ok {
+				for _, row := range values.Values {
+					row[idx] = objectTypeValue(field, row[idx])
+				}
+			}
+		}
+	}
+}
+
+// convertObjectAssignments replaces the values of object and collection fields with the binds of their types
+func convertObjectAssignments(stmtSchema *schema.Schema, set clause.Set) {
+	if stmtSchema == nil {
+		return
+	}
+	for idx, assignment := range set {
+		if field := stmtSchema.LookUpField(assignment.Column.Name); field != nil {
+			set[idx].Value = objectTypeValue(field, assignment.Value)
+		}
+	}
+}
+
+// RegisterType registers the struct of object as the Oracle object type typeName to go-ora,
+// and registers the collection type of the objects if collectionTypeName isn't empty.
+// For the collections of scalar types, object is nil and typeName is the type of elements,
+// such as VARCHAR2, NUMBER, DATE, TIMESTAMP or RAW.
+//
+//	err := oracle.RegisterType(db, "ADDRESS_T", "ADDRESS_LIST_T", Address{})
+//	err = oracle.RegisterType(db, "VARCHAR2", "PHONE_LIST_T", nil)
+//
+// The types are registered once for all the connections of the go-ora driver,
+// it does nothing if the connection pool isn't a *sql.DB of go-ora.
+func RegisterType(db *gorm.DB, typeName, collectionTypeName string, object interface{}) error {
+	if object != nil {
+		objectTypeNames.Store(reflect.TypeOf(object), typeName)
+	}
+	sqlDB, ok := db.ConnPool.(*sql.DB)
+	if !ok {
+		return nil
+	}
+	if _, ok = sqlDB.Driver().(*go_ora.OracleDriver); !ok {
+		return nil
+	}
+	return go_ora.RegisterType(sqlDB, typeName, collectionTypeName, object)
+}
+
+// RegisterTypes registers the types of Object and Collection fields of the models by their oracle tags,
+// the object types should be registered before the collections of them, e.g.
+//
+//	err := oracle.RegisterTypes(db, &Customer{})
+//
+// The type of collection elements is the struct registered, or is detected from the scalar type,
+// set the elem option to specify it, e.g. `oracle:"type:TAG_LIST_T;elem:NVARCHAR2"`
+func RegisterTypes(db *gorm.DB, models ...interface{}) error {
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return err
+		}
+		for _, field := range stmt.Schema.Fields {
+			name, ok := objectTypeName(field)
+			if !ok {
+				continue
+			}
+			valuer := reflect.New(field.IndirectFieldType).Interface().(objectTypeValuer)
+			elem := valuer.elemType()
+			if !valuer.isCollection() {
+				if err := RegisterType(db, name, "", reflect.New(elem).Elem().Interface()); err != nil {
+					return fmt.Errorf("failed to register type %s of field %s: %w", name, field.Name, err)
+				}
+				continue
+			}
+
+			var object interface{}
+			elemName := schema.ParseTagSetting(field.Tag.Get("oracle"), ";")["ELEM"]
+			if elem.Kind() == reflect.Struct && elem != reflect.TypeOf(time.Time{}) {
+				object = reflect.New(elem).Elem().Interface()
+				if v, ok := objectTypeNames.Load(elem); ok && elemName == "" {
+					elemName = v.(string)
+				}
+			} else if elemName == "" {
+				elemName = scalarTypeName(elem)
+			}
+			if elemName == "" {
+				return fmt.Errorf("unknown element type of collection %s of field %s, set it by the elem option of oracle tag",
+					name, field.Name)
+			}
+			if err := RegisterType(db, elemName, name, object); err != nil {
+				return fmt.Errorf("failed to register type %s of field %s: %w", name, field.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// scalarTypeName returns the type of the collection elements of scalar type
+func scalarTypeName(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Time{}) {
+		return "TIMESTAMP"
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "VARCHAR2"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "NUMBER"
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "RAW"
+		}
+	default:
+	}
+	return ""
+}
@@ -0,0 +1,208 @@
+package oracle
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+type testAddress struct {
+	Street string `udt:"STREET"`
+	City   string `udt:"CITY"`
+}
+
+type testCustomer struct {
+	ID        uint64                  `gorm:"primaryKey;autoIncrement:false"`
+	Name      string                  `gorm:"size:50"`
+	Address   Object[testAddress]     `oracle:"type:TEST_ADDRESS_T"`
+	Addresses Collection[testAddress] `oracle:"type:TEST_ADDRESS_LIST_T"`
+	Phones    Collection[string]      `oracle:"type:TEST_PHONE_LIST_T"`
+	Tags      Collection[string]      `oracle:"type:TEST_TAG_LIST_T;elem:NVARCHAR2"`
+	Scores    Collection[float64]     `gorm:"type:TEST_SCORE_LIST_T"`
+}
+
+func TestGoldenObjectType(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+	home := testAddress{Street: "1 Main St", City: "Springfield"}
+	office := testAddress{Street: "2 Elm St", City: "Shelbyville"}
+
+	t.Run("Migrate", func(t *testing.T) {
+		recorder.Reset()
+		if err := db.Migrator().CreateTable(&testCustomer{}); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		recorder.Reset()
+		customers := []testCustomer{
+			{ID: 1, Name: "Lisa", Address: NewObject(home), Addresses: Collection[testAddress]{home, office},
+				Phones: Collection[string]{"110", "120"}, Scores: Collection[float64]{1.5}},
+			{ID: 2, Name: "Daniela"},
+		}
+		if err := db.Create(&customers).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		recorder.Reset()
+		customer := testCustomer{ID: 2}
+		if err := db.Model(&customer).Updates(testCustomer{Address: NewObject(office), Tags: Collection[string]{"vip"}}).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Model(&customer).Update("Addresses", []testAddress{office}).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Model(&customer).Update("Address", nil).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		recorder.Reset()
+		if err := db.Exec(`UPDATE "testCustomer" SET "Phones" = ? WHERE "ID" = ?`,
+			Typed("TEST_PHONE_LIST_T", []string{"130"}), 1).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := Call(db, "PKG_CUSTOMER.MOVE", InParam(1), InParam(Typed("TEST_ADDRESS_T", NewObject(office)))).Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	got := db.Dialector.Explain(`UPDATE "testCustomer" SET "Phones" = :1`, Typed("TEST_PHONE_LIST_T", []string{"130"}))
+	if want := `UPDATE "testCustomer" SET "Phones" = '[130]'`; got != want {
+		t.Errorf("Explain() = %s, want %s", got, want)
+	}
+}
+
+func TestObjectTypeScan(t *testing.T) {
+	home := testAddress{Street: "1 Main St", City: "Springfield"}
+
+	var address Object[testAddress]
+	if err := address.Scan(home); err != nil || !address.Valid || address.V != home {
+		t.Errorf("Scan(struct) = %+v, %v", address, err)
+	}
+	if err := address.Scan(nil); err != nil || address.Valid {
+		t.Errorf("Scan(nil) = %+v, %v", address, err)
+	}
+	if err := address.Scan(&home); err != nil || !address.Valid || address.V != home {
+		t.Errorf("Scan(pointer) = %+v, %v", address, err)
+	}
+	if err := address.Scan("home"); err == nil {
+		t.Error("Scan(string) returned no error")
+	}
+
+	var phones Collection[string]
+	if err := phones.Scan([]string{"110", "120"}); err != nil || !reflect.DeepEqual(phones, Collection[string]{"110", "120"}) {
+		t.Errorf("Scan([]string) = %v, %v", phones, err)
+	}
+	if err := phones.Scan([]interface{}{"130", []byte("140")}); err != nil || !reflect.DeepEqual(phones, Collection[string]{"130", "140"}) {
+		t.Errorf("Scan([]interface{}) = %v, %v", phones, err)
+	}
+	if err := phones.Scan(nil); err != nil || phones != nil {
+		t.Errorf("Scan(nil) = %v, %v", phones, err)
+	}
+	if err := phones.Scan(110); err == nil {
+		t.Error("Scan(int) returned no error")
+	}
+
+	var scores Collection[float64]
+	if err := scores.Scan([]interface{}{int64(1), 2.5}); err != nil || !reflect.DeepEqual(scores, Collection[float64]{1, 2.5}) {
+		t.Errorf("Scan([]interface{}) = %v, %v", scores, err)
+	}
+}
+
+func TestRegisterTypes(t *testing.T) {
+	db, _ := openRecorder(t, Config{}, "19.0.0.0.0")
+	if err := RegisterTypes(db, &testCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	if name, ok := objectTypeNames.Load(reflect.TypeOf(testAddress{})); !ok || name != "TEST_ADDRESS_T" {
+		t.Errorf("the name of registered type = %v, want TEST_ADDRESS_T", name)
+	}
+
+	type testUnknownElem struct {
+		ID    uint64
+		Flags Collection[bool] `oracle:"type:TEST_FLAG_LIST_T"`
+	}
+	if err := RegisterTypes(db, &testUnknownElem{}); err == nil || !strings.Contains(err.Error(), "TEST_FLAG_LIST_T") {
+		t.Errorf("RegisterTypes() error = %v, want the error of unknown element type", err)
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(&testCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	for fieldName, want := range map[string]string{
+		"Address": "TEST_ADDRESS_T", "Tags": "TEST_TAG_LIST_T", "Scores": "TEST_SCORE_LIST_T", "Name": "",
+	} {
+		if name, _ := objectTypeName(stmt.Schema.LookUpField(fieldName)); name != want {
+			t.Errorf("objectTypeName(%s) = %q, want %q", fieldName, name, want)
+		}
+	}
+}
+
+func TestObjectType(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	type testObjectCustomer struct {
+		ID        uint64                  `gorm:"primaryKey;autoIncrement:false"`
+		Address   Object[testAddress]     `oracle:"type:TEST_ADDRESS_T"`
+		Addresses Collection[testAddress] `oracle:"type:TEST_ADDRESS_LIST_T"`
+		Phones    Collection[string]      `oracle:"type:TEST_PHONE_LIST_T"`
+	}
+	_ = db.Migrator().DropTable(&testObjectCustomer{})
+	for _, ddl := range []string{
+		`CREATE OR REPLACE TYPE TEST_ADDRESS_T AS OBJECT (STREET VARCHAR2(100), CITY VARCHAR2(50))`,
+		`CREATE OR REPLACE TYPE TEST_ADDRESS_LIST_T AS VARRAY(10) OF TEST_ADDRESS_T`,
+		`CREATE OR REPLACE TYPE TEST_PHONE_LIST_T AS VARRAY(10) OF VARCHAR2(20)`,
+	} {
+		if err = db.Exec(ddl).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = RegisterTypes(db, &testObjectCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Migrator().CreateTable(&testObjectCustomer{}); err != nil {
+		t.Fatal(err)
+	}
+
+	home := testAddress{Street: "1 Main St", City: "Springfield"}
+	customer := testObjectCustomer{ID: 1, Address: NewObject(home), Addresses: Collection[testAddress]{home},
+		Phones: Collection[string]{"110", "120"}}
+	if err = db.Create(&customer).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var got testObjectCustomer
+	if err = db.First(&got, 1).Error; err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, customer) {
+		t.Errorf("got %+v, want %+v", got, customer)
+	}
+	if err = db.Model(&got).Update("Address", nil).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = db.First(&got, 1).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatal(err)
+	}
+	if got.Address.Valid {
+		t.Errorf("the address is not NULL: %+v", got.Address)
+	}
+}
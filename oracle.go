@@ -3,11 +3,13 @@ package oracle
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	go_ora "github.com/sijms/go-ora/v2"
@@ -24,15 +26,45 @@ type Config struct {
 	DSN               string
 	Conn              gorm.ConnPool //*sql.DB
 	DefaultStringSize uint
-	DBVer             string
+	// DBVer is the version of the database server, detected on initialize,
+	// set it to specify the version when the detection fails, e.g. "19.0.0.0.0"
+	DBVer string
+
+	// Capabilities overrides the SQL features detected from the server version, see Capabilities
+	Capabilities *Capabilities
 
 	IgnoreCase          bool // warning: may cause performance issues
 	NamingCaseSensitive bool // whether naming is case-sensitive
+	// SessionParams are set in order by ALTER SESSION on every new connection of the pool opened from DSN.
+	// They can't be set on the pool of Conn, Initialize fails with *SessionParamError if both are set,
+	// open the pool of Conn by WithSessionParams instead.
+	SessionParams []SessionParam
+	// MapTxOptions maps the isolation level and read-only option of sql.TxOptions passed to db.Begin and
+	// db.Transaction to SET TRANSACTION on the pool opened from DSN, see TxOptions. The driver connections
+	// of the pool are wrapped then, open the pool of Conn by WithSessionParams to map them for Conn.
+	MapTxOptions bool
 	// whether VARCHAR type size is character length, defaulting to byte length
 	VarcharSizeIsCharLength bool
+	// NativeBoolean maps bool fields to the BOOLEAN type instead of NUMBER(1),
+	// it takes effect on Oracle 23ai or later, see Capabilities.Boolean.
+	// The existing NUMBER(1) columns aren't converted by AutoMigrate, a warning is logged for them,
+	// and only their nullability and default value are migrated.
+	NativeBoolean bool
+	// DBMSOutput captures the output of DBMS_OUTPUT after each db.Exec, the lines are logged at info level,
+	// set DBMSOutputKey by db.Set to override it for the session, see DBMSOutputLines.
+	// DBMS_OUTPUT is disabled after the statement unless it's enabled on the session before.
+	DBMSOutput bool
 
 	// RowNumberAliasForOracle11 is the alias for ROW_NUMBER() in Oracle 11g, defaulting to ROW_NUM
 	RowNumberAliasForOracle11 string
+
+	// ReturningArraySize is the initial size of the arrays returned by UPDATE or DELETE with RETURNING clause,
+	// defaulting to DefaultReturningArraySize. The statement returning more rows is rolled back and executed
+	// again with the arrays of the returned size.
+	ReturningArraySize int
+
+	// versionCapabilities caches the capabilities derived from DBVer when Capabilities is nil
+	versionCapabilities atomic.Value
 }
 
 // Dialector implement GORM database dialector
@@ -93,6 +125,9 @@ func GetStringExpr(value string, quotes ...bool) clause.Expr {
 // If the value doesn't need to be wrapped in single quotes,
 // please use the go_ora.AddSessionParam function directly,
 // or pass the originals parameter as true.
+//
+// The parameters are only registered to the go-ora driver,
+// use Config.SessionParams or WithSessionParams to set them in order on the connections of any pool.
 func AddSessionParams(db *sql.DB, params map[string]string, originals ...bool) (keys []string, err error) {
 	if db == nil {
 		return
@@ -226,30 +261,42 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 
 	if d.Conn != nil {
 		db.ConnPool = d.Conn
+		if err = d.setSessionParams(db); err != nil {
+			return
+		}
 	} else {
-		db.ConnPool, err = sql.Open(d.DriverName, d.DSN)
+		db.ConnPool, err = d.openDB()
 		if err != nil {
 			return
 		}
 	}
-	if d.IgnoreCase {
-		if sqlDB, ok := db.ConnPool.(*sql.DB); ok {
-			// warning: may cause performance issues
-			_ = go_ora.AddSessionParam(sqlDB, "NLS_COMP", "LINGUISTIC")
-			_ = go_ora.AddSessionParam(sqlDB, "NLS_SORT", "BINARY_CI")
-		}
-	}
-	err = db.ConnPool.QueryRowContext(context.Background(), "select version from product_component_version where rownum = 1").Scan(&d.DBVer)
-	if err != nil {
-		return err
+	if version := d.detectServerVersion(db); version != "" {
+		d.DBVer = version
+	} else if _, ok := ParseServerVersion(d.DBVer); !ok {
+		db.Logger.Warn(context.Background(), "failed to detect the Oracle server version, set Config.DBVer to specify it")
 	}
 	//log.Println("DBVer:" + d.DBVer)
+	if d.Capabilities == nil {
+		d.Capabilities = d.detectCapabilities(db)
+	}
 	if err = db.Callback().Create().Replace("gorm:create", Create); err != nil {
 		return
 	}
+	if err = db.Callback().Delete().Replace("gorm:delete", Delete(config)); err != nil {
+		return
+	}
 	if err = db.Callback().Update().Replace("gorm:update", Update(config)); err != nil {
 		return
 	}
+	if err = db.Callback().Raw().Before("gorm:raw").Register("oracle:before_dbms_output", beforeDBMSOutput); err != nil {
+		return
+	}
+	if err = db.Callback().Raw().After("gorm:raw").Register("oracle:after_dbms_output", afterDBMSOutput); err != nil {
+		return
+	}
+	if err = registerClientInfoCallbacks(db); err != nil {
+		return
+	}
 
 	for k, v := range d.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
@@ -260,12 +307,17 @@ func (d Dialector) Initialize(db *gorm.DB) (err error) {
 func (d Dialector) ClauseBuilders() (clauseBuilders map[string]clause.ClauseBuilder) {
 	clauseBuilders = make(map[string]clause.ClauseBuilder)
 
-	if dbVer, _ := strconv.Atoi(strings.Split(d.DBVer, ".")[0]); dbVer > 11 {
+	if d.capabilities().FetchFirst {
 		clauseBuilders["LIMIT"] = d.RewriteLimit
 	} else {
 		clauseBuilders["LIMIT"] = d.RewriteLimit11
 	}
 
+	clauseBuilders["WHERE"] = d.RewriteWhere
+	if d.capabilities().NativeJSON {
+		clauseBuilders["SELECT"] = d.RewriteSelect
+	}
+
 	clauseBuilders["RETURNING"] = func(c clause.Clause, builder clause.Builder) {
 		if returning, ok := c.Expression.(clause.Returning); ok {
 			_, _ = builder.WriteString("/*- -*/")
@@ -287,6 +339,154 @@ func (d Dialector) ClauseBuilders() (clauseBuilders map[string]clause.ClauseBuil
 	return
 }
 
+// MaxInListSize is the maximum number of expressions in an IN list, see ORA-01795
+const MaxInListSize = 1000
+
+// RewriteWhere rewrite the IN conditions with more than MaxInListSize values in the WHERE clause
+// into OR-ed IN conditions of MaxInListSize values at most
+//
+//	WHERE id IN (1, 2, ..., 1500)
+//	WHERE (id IN (1, 2, ..., 1000) OR id IN (1001, ..., 1500))
+func (d Dialector) RewriteWhere(c clause.Clause, builder clause.Builder) {
+	if where, ok := c.Expression.(clause.Where); ok {
+		if exprs := splitInExprs(where.Exprs); exprs != nil {
+			c.Expression = clause.Where{Exprs: exprs}
+		}
+	}
+	c.Build(builder)
+}
+
+// RewriteSelect serializes the native JSON columns of the model in SELECT clause,
+// since go-ora cannot decode the binary JSON type of Oracle 21c
+func (d Dialector) RewriteSelect(c clause.Clause, builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if sel, isSelect := c.Expression.(clause.Select); ok && isSelect && sel.Expression == nil && stmt.Schema != nil {
+		columns := sel.Columns
+		if len(columns) == 0 && !sel.Distinct && len(stmt.Schema.DBNames) > 0 && d.hasJSONField(stmt.Schema) {
+			for _, dbName := range stmt.Schema.DBNames {
+				columns = append(columns, clause.Column{Table: clause.CurrentTable, Name: dbName})
+			}
+		}
+
+		var rewritten bool
+		for idx, column := range columns {
+			if column.Raw || column.Alias != "" || (column.Table != "" && column.Table != clause.CurrentTable && column.Table != stmt.Table) {
+				continue
+			}
+			if field := stmt.Schema.LookUpField(column.Name); field != nil && d.isJSONField(field) {
+				if !rewritten {
+					columns, rewritten = append([]clause.Column(nil), columns...), true
+				}
+				var name strings.Builder
+				_, _ = name.WriteString("JSON_SERIALIZE(")
+				if column.Table != "" {
+					d.QuoteTo(&name, stmt.Table)
+					_ = name.WriteByte('.')
+				}
+				d.QuoteTo(&name, field.DBName)
+				_, _ = name.WriteString(" RETURNING CLOB) AS ")
+				d.QuoteTo(&name, field.DBName)
+				columns[idx] = clause.Column{Name: name.String(), Raw: true}
+			}
+		}
+		if rewritten {
+			sel.Columns = columns
+			c.Expression = sel
+		}
+	}
+	c.Build(builder)
+}
+
+// isJSONField reports whether the field is a JSON column, see JSONDataType
+func (d Dialector) isJSONField(field *schema.Field) bool {
+	return strings.EqualFold(string(field.DataType), string(JSONDataType))
+}
+
+// hasJSONField reports whether the schema has any JSON column
+func (d Dialector) hasJSONField(s *schema.Schema) bool {
+	for _, field := range s.Fields {
+		if field.DBName != "" && d.isJSONField(field) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitInExprs returns nil if there is no IN condition to split
+func splitInExprs(exprs []clause.Expression) (result []clause.Expression) {
+	for idx, expr := range exprs {
+		var split clause.Expression
+		switch v := expr.(type) {
+		case clause.IN:
+			if len(v.Values) > MaxInListSize {
+				split = splitInValues(v.Column, v.Values)
+			}
+		case clause.Eq:
+			// Eq is built as IN for slice values
+			if values, ok := inListValues(v.Value); ok {
+				split = splitInValues(v.Column, values)
+			}
+		case clause.Neq:
+			if values, ok := inListValues(v.Value); ok {
+				split = clause.NotConditions{Exprs: []clause.Expression{splitInValues(v.Column, values)}}
+			}
+		case clause.AndConditions:
+			if e := splitInExprs(v.Exprs); e != nil {
+				split = clause.AndConditions{Exprs: e}
+			}
+		case clause.OrConditions:
+			if e := splitInExprs(v.Exprs); e != nil {
+				split = clause.OrConditions{Exprs: e}
+			}
+		case clause.NotConditions:
+			if e := splitInExprs(v.Exprs); e != nil {
+				split = clause.NotConditions{Exprs: e}
+			}
+		}
+
+		if split != nil {
+			if result == nil {
+				// copy on write, the expressions of the statement clauses are not modified
+				result = make([]clause.Expression, len(exprs))
+				copy(result, exprs)
+			}
+			result[idx] = split
+		}
+	}
+	return
+}
+
+func splitInValues(column interface{}, values []interface{}) clause.Expression {
+	or := clause.OrConditions{}
+	for i := 0; i < len(values); i += MaxInListSize {
+		end := i + MaxInListSize
+		if end > len(values) {
+			end = len(values)
+		}
+		or.Exprs = append(or.Exprs, clause.IN{Column: column, Values: values[i:end]})
+	}
+	return or
+}
+
+// inListValues returns the values of a slice which is longer than MaxInListSize
+func inListValues(value interface{}) (values []interface{}, ok bool) {
+	if _, isValuer := value.(driver.Valuer); isValuer {
+		return
+	}
+	rv := reflect.ValueOf(value)
+	if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() <= MaxInListSize {
+		return
+	}
+	if _, isBytes := value.([]byte); isBytes {
+		return
+	}
+	values = make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, true
+}
+
 func (d Dialector) getLimitRows(limit clause.Limit) (limitRows int, hasLimit bool) {
 	if l := limit.Limit; l != nil {
 		limitRows = *l
@@ -519,18 +719,32 @@ func (d Dialector) QuoteTo(writer clause.Writer, str string) {
 var numericPlaceholder = regexp.MustCompile(`:(\d+)`)
 
 func (d Dialector) Explain(sql string, vars ...interface{}) string {
+	nativeBoolean := d.useNativeBoolean()
 	for idx, val := range vars {
 		switch v := ptrDereference(val).(type) {
 		case bool:
-			if v {
-				vars[idx] = 1
-			} else {
-				vars[idx] = 0
-			}
+			vars[idx] = convertBool(v, nativeBoolean)
 		case go_ora.Clob:
 			vars[idx] = v.String
+		case go_ora.Vector:
+			vars[idx] = vectorText(v)
+		case go_ora.Out:
+			vars[idx] = ptrDereference(v.Dest)
+		case go_ora.Object:
+			vars[idx] = v.Value
 		}
 	}
+	if nativeBoolean {
+		// BOOLEAN 字面量不能作为参数值传入 ExplainSQL，先替换其占位符
+		sql = numericPlaceholder.ReplaceAllStringFunc(sql, func(placeholder string) string {
+			if n, _ := strconv.Atoi(placeholder[1:]); n > 0 && n <= len(vars) {
+				if v, ok := vars[n-1].(go_ora.PLBool); ok {
+					return strings.ToUpper(strconv.FormatBool(bool(v)))
+				}
+			}
+			return placeholder
+		})
+	}
 	return logger.ExplainSQL(sql, numericPlaceholder, `'`, vars...)
 }
 
@@ -541,13 +755,21 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 	switch field.DataType {
 	case schema.Bool:
 		sqlType = "NUMBER(1)"
+		if d.useNativeBoolean() {
+			sqlType = "BOOLEAN"
+		}
 	case schema.Int, schema.Uint:
 		sqlType = "INTEGER"
 		if field.Size > 0 && field.Size <= 8 {
 			sqlType = "SMALLINT"
 		}
 
-		if field.AutoIncrement {
+		if sequence, ok := d.SequenceOf(field); ok {
+			// Oracle 11g fills the column with sequence by trigger
+			if d.capabilities().IdentityColumns {
+				sqlType += " DEFAULT " + d.quoteName(sequence) + ".NEXTVAL"
+			}
+		} else if field.AutoIncrement {
 			sqlType += " GENERATED BY DEFAULT AS IDENTITY"
 		}
 	case schema.Float:
@@ -568,10 +790,11 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 			}
 		}
 
-		if size > 0 && size <= 4000 {
-			// 默认情况下 VARCHAR2 可以指定一个不超过 4000 的正整数作为字节长度
+		maxSize := d.capabilities().MaxStringSize()
+		if size > 0 && size <= maxSize {
+			// 默认情况下 VARCHAR2 可以指定一个不超过 4000 的正整数作为字节长度，MAX_STRING_SIZE = EXTENDED 时为 32767
 			if d.VarcharSizeIsCharLength {
-				if size*3 > 4000 {
+				if size*3 > maxSize {
 					sqlType = "CLOB"
 				} else {
 					sqlType = fmt.Sprintf("VARCHAR2(%d CHAR)", size) // 字符长度（size * 3）
@@ -582,6 +805,19 @@ func (d Dialector) DataTypeOf(field *schema.Field) string {
 		} else {
 			sqlType = "CLOB"
 		}
+	case VectorDataType, "VECTOR":
+		// the migrator rejects it with ErrVectorUnsupported before 23ai
+		sqlType = vectorDataTypeOf(field)
+	case JSONDataType, "JSON":
+		sqlType = "CLOB" // with IS JSON check constraint, see Migrator.FullDataTypeOf
+		if d.capabilities().NativeJSON {
+			sqlType = "JSON"
+		}
+	case ObjectDataType, CollectionDataType:
+		sqlType = string(field.DataType)
+		if name, ok := objectTypeName(field); ok {
+			sqlType = name
+		}
 	case schema.Time:
 		sqlType = "TIMESTAMP WITH TIME ZONE"
 	case schema.Bytes:
@@ -1,6 +1,20 @@
 package oracle
 
-import "github.com/sijms/go-ora/v2"
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
 
 type (
 	RefCursor struct {
@@ -24,3 +38,367 @@ func (cursor *RefCursor) Query() (dataset *DataSet, err error) {
 	dataset = &DataSet{DataSet: *d}
 	return
 }
+
+// Rows opens the cursor and returns the rows for streaming, e.g.
+//
+//	rows, err := cursor.Rows()
+//	if err != nil {
+//		return err
+//	}
+//	defer rows.Close()
+//	for rows.Next() {
+//		var user User
+//		if err = rows.ScanRow(db, &user); err != nil {
+//			return err
+//		}
+//	}
+//	return rows.Err()
+func (cursor *RefCursor) Rows() (*CursorRows, error) {
+	dataset, err := cursor.RefCursor.Query()
+	if err != nil {
+		return nil, err
+	}
+	return newCursorRows(dataset), nil
+}
+
+// Scan scans all the rows of the cursor into dest like db.Find, e.g. &[]User{}, &user or &[]map[string]interface{}{},
+// the columns are matched with the fields of the model case-insensitively
+func (cursor *RefCursor) Scan(db *gorm.DB, dest interface{}) error {
+	rows, err := cursor.Rows()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	return rows.scan(db, dest, 0)
+}
+
+// cursorDataSet is the rows of cursor fetched by go-ora
+type cursorDataSet interface {
+	Columns() []string
+	Next(dest []driver.Value) error
+	Close() error
+}
+
+// CursorRows is the rows of RefCursor, it implements gorm.Rows
+type CursorRows struct {
+	dataset cursorDataSet
+	columns []string
+	values  []driver.Value
+	err     error
+}
+
+func newCursorRows(dataset cursorDataSet) *CursorRows {
+	columns := dataset.Columns()
+	return &CursorRows{dataset: dataset, columns: columns, values: make([]driver.Value, len(columns))}
+}
+
+// Columns returns the column names of the cursor
+func (rows *CursorRows) Columns() ([]string, error) {
+	// gorm.Scan replaces the names of ColumnMapping in place
+	return append([]string(nil), rows.columns...), nil
+}
+
+// ColumnTypes returns nil, the column types of cursor are not available in database/sql
+func (rows *CursorRows) ColumnTypes() ([]*sql.ColumnType, error) {
+	return nil, nil
+}
+
+// Next prepares the next row for scanning, it returns false if there are no more rows or an error occurs
+func (rows *CursorRows) Next() bool {
+	if rows.err != nil {
+		return false
+	}
+	if err := rows.dataset.Next(rows.values); err != nil {
+		if !errors.Is(err, io.EOF) {
+			rows.err = err
+		}
+		return false
+	}
+	return true
+}
+
+// Scan copies the columns of the current row into dest, like sql.Rows.Scan
+func (rows *CursorRows) Scan(dest ...interface{}) error {
+	if len(dest) != len(rows.values) {
+		return fmt.Errorf("expected %d destination arguments in Scan, not %d", len(rows.values), len(dest))
+	}
+	for idx, value := range rows.values {
+		if err := assignCursorValue(dest[idx], value); err != nil {
+			return fmt.Errorf("converting column index %d, name %q: %w", idx, rows.columns[idx], err)
+		}
+	}
+	return nil
+}
+
+// ScanRow scans the current row into dest like db.ScanRows, it should be called after Next
+func (rows *CursorRows) ScanRow(db *gorm.DB, dest interface{}) error {
+	return rows.scan(db, dest, gorm.ScanInitialized)
+}
+
+// Err returns the error occurred during iteration
+func (rows *CursorRows) Err() error {
+	return rows.err
+}
+
+// Close closes the rows, the cursor should be closed by its owner
+func (rows *CursorRows) Close() error {
+	return rows.dataset.Close()
+}
+
+// scan scans the rows into dest by gorm.Scan with the schema of dest
+func (rows *CursorRows) scan(db *gorm.DB, dest interface{}, mode gorm.ScanMode) error {
+	return scanRows(db, rows, dest, mode)
+}
+
+// scanRows scans the rows of cursor or implicit result set into dest by gorm.Scan with the schema of dest
+func scanRows(db *gorm.DB, rows gorm.Rows, dest interface{}, mode gorm.ScanMode) error {
+	tx := db.Session(&gorm.Session{NewDB: true})
+	if err := tx.Statement.Parse(dest); err != nil && !errors.Is(err, schema.ErrUnsupportedDataType) {
+		return err
+	}
+	tx.Statement.Dest = dest
+	tx.Statement.ReflectValue = reflect.ValueOf(dest)
+	for tx.Statement.ReflectValue.Kind() == reflect.Ptr {
+		if tx.Statement.ReflectValue.IsNil() {
+			return gorm.ErrInvalidValue
+		}
+		tx.Statement.ReflectValue = tx.Statement.ReflectValue.Elem()
+	}
+	if tx.Statement.Schema != nil {
+		columns, err := rows.Columns()
+		if err != nil {
+			return err
+		}
+		tx.Statement.ColumnMapping = cursorColumnMapping(columns, tx.Statement.Schema)
+	}
+
+	gorm.Scan(rows, tx, mode)
+	if err := rows.Err(); err != nil {
+		_ = tx.AddError(err)
+	}
+	return tx.Error
+}
+
+// ResultSets are the implicit result sets returned by DBMS_SQL.RETURN_RESULT of PL/SQL since 12c, e.g.
+//
+//	results, err := oracle.ImplicitResults(db, "BEGIN PKG_REPORT.SUMMARY(:1); END;", year)
+//	if err != nil {
+//		return err
+//	}
+//	defer results.Close()
+//	var orders []Order
+//	var customers []Customer
+//	if err = results.Scan(&orders); err != nil { // the first result set
+//		return err
+//	}
+//	if results.NextResultSet() {
+//		err = results.Scan(&customers)
+//	}
+type ResultSets struct {
+	db   *gorm.DB
+	rows resultSetRows
+}
+
+// ImplicitResults runs the anonymous PL/SQL block by db.Raw and returns its implicit result sets,
+// the first result set is current
+func ImplicitResults(db *gorm.DB, block string, values ...interface{}) (*ResultSets, error) {
+	rows, err := db.Raw(block, values...).Rows()
+	if err != nil {
+		return nil, err
+	}
+	return &ResultSets{db: db, rows: resultSetRows{Rows: rows}}, nil
+}
+
+// Columns returns the column names of the current result set
+func (results *ResultSets) Columns() ([]string, error) {
+	return results.rows.Columns()
+}
+
+// Next prepares the next row of the current result set for ScanRow
+func (results *ResultSets) Next() bool {
+	return results.rows.Next()
+}
+
+// ScanRow scans the current row into dest like db.ScanRows, it should be called after Next
+func (results *ResultSets) ScanRow(dest interface{}) error {
+	return scanRows(results.db, results.rows, dest, gorm.ScanInitialized)
+}
+
+// Scan scans all the remaining rows of the current result set into dest like db.Find
+func (results *ResultSets) Scan(dest interface{}) error {
+	return scanRows(results.db, results.rows, dest, 0)
+}
+
+// NextResultSet moves to the next result set, it returns false if there are no more result sets
+func (results *ResultSets) NextResultSet() bool {
+	return results.rows.NextResultSet()
+}
+
+// Err returns the error occurred during iteration
+func (results *ResultSets) Err() error {
+	return results.rows.Err()
+}
+
+// Close closes all the result sets
+func (results *ResultSets) Close() error {
+	return results.rows.Close()
+}
+
+// resultSetRows converts the values of the result set like CursorRows
+type resultSetRows struct {
+	*sql.Rows
+}
+
+// Scan copies the columns of the current row into dest with assignCursorValue
+func (rows resultSetRows) Scan(dest ...interface{}) error {
+	values := make([]interface{}, len(dest))
+	ptrs := make([]interface{}, len(dest))
+	for idx := range values {
+		ptrs[idx] = &values[idx]
+	}
+	if err := rows.Rows.Scan(ptrs...); err != nil {
+		return err
+	}
+	for idx, value := range values {
+		if err := assignCursorValue(dest[idx], value); err != nil {
+			return fmt.Errorf("converting column index %d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
+// cursorColumnMapping maps the columns of cursor to the fields of schema, they're matched case-insensitively
+// because the names of unquoted columns are upper case, while the names of fields depend on Namer
+func cursorColumnMapping(columns []string, sch *schema.Schema) map[string]string {
+	mapping := make(map[string]string, len(columns))
+	for _, column := range columns {
+		if sch.LookUpField(column) != nil {
+			continue
+		}
+		for _, field := range sch.Fields {
+			if field.DBName != "" && strings.EqualFold(field.DBName, column) {
+				mapping[column] = field.DBName
+				break
+			}
+		}
+	}
+	return mapping
+}
+
+// assignCursorValue copies the value fetched by go-ora into dest, sql.Scanner is preferred,
+// and the custom time types are converted from time.Time, as opposed to convertCustomType
+func assignCursorValue(dest interface{}, src driver.Value) error {
+	switch d := dest.(type) {
+	case nil:
+		return errors.New("destination is nil")
+	case *interface{}:
+		if b, ok := src.([]byte); ok {
+			src = append([]byte(nil), b...)
+		}
+		*d = src
+		return nil
+	case sql.Scanner:
+		return d.Scan(src)
+	}
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("destination not a pointer: %T", dest)
+	}
+	dv = dv.Elem()
+	if src == nil {
+		dv.Set(reflect.Zero(dv.Type()))
+		return nil
+	}
+	if dv.Kind() == reflect.Ptr {
+		value := reflect.New(dv.Type().Elem())
+		if err := assignCursorValue(value.Interface(), src); err != nil {
+			return err
+		}
+		dv.Set(value)
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if b, ok := src.([]byte); ok {
+		sv = reflect.ValueOf(append([]byte(nil), b...))
+	}
+	if sv.Type().AssignableTo(dv.Type()) {
+		dv.Set(sv)
+		return nil
+	}
+	if t, ok := src.(time.Time); ok && dv.Kind() == reflect.Struct {
+		// custom time type, e.g. type LocalTime time.Time or struct{ time.Time }
+		if sv.Type().ConvertibleTo(dv.Type()) {
+			dv.Set(sv.Convert(dv.Type()))
+			return nil
+		} else if field := dv.FieldByName("Time"); field.IsValid() && field.CanSet() && field.Type() == sv.Type() {
+			field.Set(reflect.ValueOf(t))
+			return nil
+		}
+	}
+
+	text := cursorValueText(src)
+	switch dv.Kind() {
+	case reflect.String:
+		dv.SetString(text)
+		return nil
+	case reflect.Bool:
+		// NUMBER(1) of bool fields
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a bool: %w", src, text, err)
+		}
+		dv.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(strings.TrimSuffix(text, ".0"), 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, text, dv.Kind(), err)
+		}
+		dv.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(strings.TrimSuffix(text, ".0"), 10, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, text, dv.Kind(), err)
+		}
+		dv.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, dv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting driver.Value type %T (%q) to a %s: %w", src, text, dv.Kind(), err)
+		}
+		dv.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if dv.Type().Elem().Kind() == reflect.Uint8 {
+			dv.SetBytes([]byte(text))
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported Scan, storing driver.Value type %T into type %T", src, dest)
+}
+
+// cursorValueText returns the text of the value fetched by go-ora for the conversion
+func cursorValueText(src driver.Value) string {
+	switch v := src.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -8,7 +8,11 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/godoes/gorm-oracle/oracletest"
 )
 
 const (
@@ -104,8 +108,8 @@ func ExampleRefCursor_Query() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	// the example requires a database, it is verified by TestExecProcedure
 	fmt.Println(len(dataRows) > 0)
-	//Output: true
 }
 
 func TestExecProcedure(t *testing.T) {
@@ -114,8 +118,7 @@ func TestExecProcedure(t *testing.T) {
 		t.Fatal(err)
 	}
 	if db == nil {
-		t.Log("db is nil!")
-		return
+		t.Skip("db is nil!")
 	}
 	if err = db.Exec(procCreateExamplePagingQuery).Error; err != nil {
 		t.Fatal(err)
@@ -173,6 +176,305 @@ func TestExecProcedure(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	if len(dataRows) == 0 {
+		t.Error("the cursor returned no rows")
+	}
 	got, _ := json.Marshal(dataRows)
 	t.Logf("got total: %d, got size: %d, got data:\n%s", totalNum, len(dataRows), got)
 }
+
+// testDataSet is the rows of cursor in memory
+type testDataSet struct {
+	columns []string
+	rows    [][]driver.Value
+	closed  bool
+}
+
+func (d *testDataSet) Columns() []string {
+	return d.columns
+}
+
+func (d *testDataSet) Next(dest []driver.Value) error {
+	if len(d.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, d.rows[0])
+	d.rows = d.rows[1:]
+	return nil
+}
+
+func (d *testDataSet) Close() error {
+	d.closed = true
+	return nil
+}
+
+type testCursorTime time.Time
+
+type testCursorUser struct {
+	ID       uint64
+	Name     string
+	Nickname sql.NullString
+	Enabled  bool
+	Score    *float64
+	Birthday testCursorTime
+	Remark   string `gorm:"column:remark_text"`
+}
+
+func newTestDataSet() *testDataSet {
+	birthday := time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)
+	return &testDataSet{
+		columns: []string{"ID", "NAME", "NICKNAME", "ENABLED", "SCORE", "BIRTHDAY", "REMARK_TEXT", "UNKNOWN"},
+		rows: [][]driver.Value{
+			{int64(1), "Lisa", "lisa", int64(1), 9.5, birthday, "first", "x"},
+			{int64(2), "Daniela", nil, int64(0), nil, nil, nil, nil},
+		},
+	}
+}
+
+func TestCursorRowsScan(t *testing.T) {
+	db, _ := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+	score := 9.5
+	want := []testCursorUser{
+		{ID: 1, Name: "Lisa", Nickname: sql.NullString{String: "lisa", Valid: true}, Enabled: true, Score: &score,
+			Birthday: testCursorTime(time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC)), Remark: "first"},
+		{ID: 2, Name: "Daniela"},
+	}
+
+	t.Run("Slice", func(t *testing.T) {
+		dataset := newTestDataSet()
+		var users []testCursorUser
+		if err := newCursorRows(dataset).scan(db, &users, 0); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(users, want) {
+			t.Errorf("got users %+v, want %+v", users, want)
+		}
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		dataset := newTestDataSet()
+		rows := newCursorRows(dataset)
+		var users []testCursorUser
+		for rows.Next() {
+			var user testCursorUser
+			if err := rows.ScanRow(db, &user); err != nil {
+				t.Fatal(err)
+			}
+			users = append(users, user)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		if err := rows.Close(); err != nil || !dataset.closed {
+			t.Errorf("failed to close the rows: %v", err)
+		}
+		if !reflect.DeepEqual(users, want) {
+			t.Errorf("got users %+v, want %+v", users, want)
+		}
+	})
+
+	t.Run("Maps", func(t *testing.T) {
+		var results []map[string]interface{}
+		if err := newCursorRows(newTestDataSet()).scan(db, &results, 0); err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 2 || results[0]["NAME"] != "Lisa" || results[1]["SCORE"] != nil {
+			t.Errorf("got results %v", results)
+		}
+	})
+
+	t.Run("Pluck", func(t *testing.T) {
+		dataset := &testDataSet{columns: []string{"NAME"}, rows: [][]driver.Value{{"Lisa"}, {"Daniela"}}}
+		var names []string
+		if err := newCursorRows(dataset).scan(db, &names, 0); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(names, []string{"Lisa", "Daniela"}) {
+			t.Errorf("got names %v", names)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		dataset := &testDataSet{columns: []string{"ID"}, rows: [][]driver.Value{{"one"}}}
+		var users []testCursorUser
+		if err := newCursorRows(dataset).scan(db, &users, 0); err == nil {
+			t.Error("expected the conversion error of ID")
+		}
+	})
+}
+
+func TestRefCursorScan(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+	if err = db.Exec(procCreateExamplePagingQuery).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	type userTable struct {
+		TableName    string
+		Tablespace   sql.NullString `gorm:"column:TABLESPACE_NAME"`
+		NumRows      *int64
+		LastAnalyzed *time.Time
+	}
+	query := func(cursor *RefCursor) {
+		var total uint
+		if err = Call(db, "PRO_EXAMPLE_PAGING_QUERY",
+			InParam("SELECT * FROM USER_TABLES"), InParam("TABLE_NAME"), InParam(1), InParam(10), OutParam(&total), OutParam(cursor),
+		).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var cursor RefCursor
+	query(&cursor)
+	var tables []userTable
+	err = cursor.Scan(db, &tables)
+	_ = cursor.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tables) == 0 || tables[0].TableName == "" {
+		t.Errorf("got tables %+v", tables)
+	}
+
+	var stream RefCursor
+	query(&stream)
+	defer func() {
+		_ = stream.Close()
+	}()
+	rows, err := stream.Rows()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var count int
+	for rows.Next() {
+		var table userTable
+		if err = rows.ScanRow(db, &table); err != nil {
+			t.Fatal(err)
+		}
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != len(tables) {
+		t.Errorf("got %d rows by streaming, want %d", count, len(tables))
+	}
+}
+
+func TestImplicitResults(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+	dataset := newTestDataSet()
+	recorder.Script(`^BEGIN PKG_REPORT\.SUMMARY`, oracletest.Result{
+		Columns: dataset.columns,
+		Rows:    dataset.rows,
+		ResultSets: []oracletest.Result{
+			{Columns: []string{"NAME", "TOTAL"}, Rows: [][]driver.Value{{"Lisa", int64(3)}, {"Daniela", int64(5)}}},
+			{Columns: []string{"NAME"}, Rows: [][]driver.Value{{"Lisa"}}},
+		},
+	})
+
+	results, err := ImplicitResults(db, "BEGIN PKG_REPORT.SUMMARY(?); END;", 2024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = results.Close()
+	}()
+
+	var users []testCursorUser
+	if err = results.Scan(&users); err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].Name != "Lisa" || !users[0].Enabled || users[1].Nickname.Valid {
+		t.Errorf("got users %+v", users)
+	}
+
+	if !results.NextResultSet() {
+		t.Fatal("expected the second result set")
+	}
+	type total struct {
+		Name  string
+		Total int
+	}
+	var totals []total
+	for results.Next() {
+		var row total
+		if err = results.ScanRow(&row); err != nil {
+			t.Fatal(err)
+		}
+		totals = append(totals, row)
+	}
+	if want := []total{{"Lisa", 3}, {"Daniela", 5}}; !reflect.DeepEqual(totals, want) {
+		t.Errorf("got totals %+v, want %+v", totals, want)
+	}
+
+	if !results.NextResultSet() {
+		t.Fatal("expected the third result set")
+	}
+	var names []string
+	if err = results.Scan(&names); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(names, []string{"Lisa"}) {
+		t.Errorf("got names %v", names)
+	}
+	if results.NextResultSet() {
+		t.Error("expected no more result sets")
+	}
+	if err = results.Err(); err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, recorder)
+}
+
+func TestImplicitResultsDB(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	results, err := ImplicitResults(db, `
+	DECLARE
+		c1 SYS_REFCURSOR;
+		c2 SYS_REFCURSOR;
+	BEGIN
+		OPEN c1 FOR SELECT TABLE_NAME FROM USER_TABLES WHERE ROWNUM <= 3;
+		DBMS_SQL.RETURN_RESULT(c1);
+		OPEN c2 FOR SELECT SYSDATE AS NOW FROM DUAL;
+		DBMS_SQL.RETURN_RESULT(c2);
+	END;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = results.Close()
+	}()
+
+	var tables []string
+	if err = results.Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+	if !results.NextResultSet() {
+		t.Fatal("expected the second result set")
+	}
+	var now time.Time
+	if err = results.Scan(&now); err != nil {
+		t.Fatal(err)
+	}
+	if now.IsZero() {
+		t.Error("got zero time of the second result set")
+	}
+	t.Logf("got tables: %v, now: %v", tables, now)
+}
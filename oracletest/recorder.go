@@ -0,0 +1,397 @@
+// Package oracletest provides an offline database connection for testing the SQL generated by the Oracle dialector,
+// it records the executed statements with their binds and returns scripted rows without an Oracle database.
+//
+//	recorder := oracletest.NewRecorder()
+//	db, _ := gorm.Open(oracle.New(oracle.Config{Conn: recorder.DB()}), &gorm.Config{})
+//	db.Create(&user)
+//	fmt.Println(recorder)
+package oracletest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sijms/go-ora/v2"
+)
+
+// DefaultVersion is the database version returned by the version query of the dialector
+const DefaultVersion = "19.0.0.0.0"
+
+var versionQuery = regexp.MustCompile(`(?i)\bPRODUCT_COMPONENT_VERSION\b|\bV\$VERSION\b|\bV\$INSTANCE\b`)
+
+// Statement is a statement executed through the recorder
+type Statement struct {
+	SQL   string
+	Args  []interface{}
+	Query bool
+}
+
+// String returns the statement and its binds in a stable format
+func (s Statement) String() string {
+	var builder strings.Builder
+	if s.Query {
+		builder.WriteString("QUERY: ")
+	} else {
+		builder.WriteString("EXEC: ")
+	}
+	builder.WriteString(s.SQL)
+	if len(s.Args) > 0 {
+		builder.WriteString("\n  ARGS: ")
+		for idx, arg := range s.Args {
+			if idx > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(FormatArg(arg))
+		}
+	}
+	return builder.String()
+}
+
+// Result is the scripted result of the statements matching a pattern
+type Result struct {
+	// Columns and Rows are returned by queries
+	Columns []string
+	Rows    [][]driver.Value
+	// ResultSets are the next result sets returned by queries, like the implicit results of PL/SQL
+	ResultSets []Result
+	// RowsAffected is returned by executions, defaulting to 1
+	RowsAffected int64
+	// Out are assigned to the out binds of executions in order, e.g. the OUT parameters of procedures
+	Out []interface{}
+	// Err is returned instead of the result if not nil
+	Err error
+}
+
+type script struct {
+	pattern *regexp.Regexp
+	result  Result
+}
+
+// Recorder records the statements executed through its DB
+type Recorder struct {
+	// Version is returned by the version queries, defaulting to DefaultVersion
+	Version string
+
+	mu         sync.Mutex
+	statements []Statement
+	scripts    []script
+}
+
+// NewRecorder returns a new recorder
+func NewRecorder() *Recorder {
+	return &Recorder{Version: DefaultVersion}
+}
+
+// DB returns a *sql.DB executing statements through the recorder, it can be used as oracle.Config.Conn
+func (r *Recorder) DB() *sql.DB {
+	return sql.OpenDB(r.Connector())
+}
+
+// Connector returns the connector of the recorder, every connection it opens executes statements through the recorder
+func (r *Recorder) Connector() driver.Connector {
+	return connector{recorder: r}
+}
+
+// Script sets the result of the statements matching the regular expression pattern,
+// the scripts are matched in the order they are added
+func (r *Recorder) Script(pattern string, result Result) *Recorder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scripts = append(r.scripts, script{pattern: regexp.MustCompile(pattern), result: result})
+	return r
+}
+
+// Statements returns the recorded statements
+func (r *Recorder) Statements() []Statement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Statement(nil), r.statements...)
+}
+
+// Reset clears the recorded statements, the scripts are kept
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = nil
+}
+
+// String returns the recorded statements, one per line
+func (r *Recorder) String() string {
+	var builder strings.Builder
+	for _, stmt := range r.Statements() {
+		builder.WriteString(stmt.String())
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue, isQuery bool) Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stmt := Statement{SQL: query, Query: isQuery}
+	for _, arg := range args {
+		stmt.Args = append(stmt.Args, arg.Value)
+	}
+	r.statements = append(r.statements, stmt)
+
+	for _, s := range r.scripts {
+		if s.pattern.MatchString(query) {
+			return s.result
+		}
+	}
+	if isQuery && versionQuery.MatchString(query) {
+		version := r.Version
+		if version == "" {
+			version = DefaultVersion
+		}
+		return Result{Columns: []string{"VERSION"}, Rows: [][]driver.Value{{version}}}
+	}
+	return Result{RowsAffected: 1}
+}
+
+// FormatArg formats the bind value in a stable format, pointers are dereferenced and out binds show their types
+func FormatArg(arg interface{}) string {
+	switch v := arg.(type) {
+	case nil:
+		return "NULL"
+	case go_ora.Out:
+		if v.In {
+			return fmt.Sprintf("IN OUT(%s, size=%d)", FormatArg(v.Dest), v.Size)
+		}
+		return fmt.Sprintf("OUT(%T, size=%d)", v.Dest, v.Size)
+	case *go_ora.Out:
+		return FormatArg(*v)
+	case go_ora.Vector:
+		return fmt.Sprintf("VECTOR(%v)", v.Data)
+	case go_ora.Object:
+		return fmt.Sprintf("OBJECT(%s, %s)", v.Name, FormatArg(v.Value))
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []byte:
+		return fmt.Sprintf("0x%X", v)
+	case string:
+		return fmt.Sprintf("%q", v)
+	case driver.Valuer:
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL"
+		}
+		value, err := v.Value()
+		if err != nil {
+			return fmt.Sprintf("ERROR(%v)", err)
+		}
+		return FormatArg(value)
+	}
+
+	rv := reflect.ValueOf(arg)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return FormatArg(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		for i := range items {
+			items[i] = FormatArg(rv.Index(i).Interface())
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	default:
+		return fmt.Sprintf("%v", arg)
+	}
+}
+
+type connector struct {
+	recorder *Recorder
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{recorder: c.recorder}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return recorderDriver{recorder: c.recorder}
+}
+
+type recorderDriver struct {
+	recorder *Recorder
+}
+
+func (d recorderDriver) Open(string) (driver.Conn, error) {
+	return &conn{recorder: d.recorder}, nil
+}
+
+type conn struct {
+	recorder *Recorder
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{recorder: c.recorder}, nil
+}
+
+// BeginTx rejects the non-default options like go-ora
+func (c *conn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if opts.ReadOnly {
+		return nil, errors.New("readonly transaction is not supported")
+	}
+	if opts.Isolation != 0 {
+		return nil, errors.New("only support default value for isolation")
+	}
+	return tx{recorder: c.recorder}, nil
+}
+
+// CheckNamedValue accepts all the bind values as they are, like the out binds and arrays of go-ora
+func (c *conn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	// the canceled statements aren't executed, like go-ora
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result := c.recorder.record(query, args, false)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	if err := assignOut(args, result.Out); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(result.RowsAffected), nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	result := c.recorder.record(query, args, true)
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	return &rows{columns: result.Columns, values: result.Rows, next: result.ResultSets}, nil
+}
+
+// assignOut assigns the values to the destinations of out binds in order
+func assignOut(args []driver.NamedValue, values []interface{}) error {
+	for _, arg := range args {
+		if len(values) == 0 {
+			return nil
+		}
+		var dest interface{}
+		switch v := arg.Value.(type) {
+		case go_ora.Out:
+			dest = v.Dest
+		case *go_ora.Out:
+			dest = v.Dest
+		case sql.Out:
+			dest = v.Dest
+		case *sql.Out:
+			dest = v.Dest
+		default:
+			continue
+		}
+		rv, value := reflect.ValueOf(dest), reflect.ValueOf(values[0])
+		if rv.Kind() != reflect.Ptr || rv.IsNil() || !value.Type().ConvertibleTo(rv.Elem().Type()) {
+			return fmt.Errorf("oracletest: cannot assign %T to out bind %T", values[0], dest)
+		}
+		rv.Elem().Set(value.Convert(rv.Elem().Type()))
+		values = values[1:]
+	}
+	return nil
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("oracletest: Exec without context is not supported")
+}
+
+func (s *stmt) Query([]driver.Value) (driver.Rows, error) {
+	return nil, errors.New("oracletest: Query without context is not supported")
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+// tx records COMMIT and ROLLBACK as statements, their errors can be scripted
+type tx struct {
+	recorder *Recorder
+}
+
+func (t tx) Commit() error {
+	return t.recorder.record("COMMIT", nil, false).Err
+}
+
+func (t tx) Rollback() error {
+	return t.recorder.record("ROLLBACK", nil, false).Err
+}
+
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+	idx     int
+	next    []Result
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.idx])
+	r.idx++
+	return nil
+}
+
+func (r *rows) HasNextResultSet() bool {
+	return len(r.next) > 0
+}
+
+func (r *rows) NextResultSet() error {
+	if len(r.next) == 0 {
+		return io.EOF
+	}
+	r.columns, r.values, r.idx = r.next[0].Columns, r.next[0].Rows, 0
+	r.next = r.next[1:]
+	return nil
+}
@@ -0,0 +1,261 @@
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// DefaultReturningArraySize is the default initial size of the arrays returned by
+// UPDATE or DELETE statements with RETURNING clause
+const DefaultReturningArraySize = 1000
+
+// returningMarker is written before the RETURNING clause by the clause builder
+const returningMarker = "/*- -*/"
+
+// returningOverflowErrCode is the code of the error raised by the PL/SQL block built by buildReturningInto
+// when the statement returns more rows than the size of the arrays, i.e. ORA-20999
+const returningOverflowErrCode = 20999
+
+// maxReturningAttempts is the maximum number of times the statement is executed with resized arrays
+const maxReturningAttempts = 3
+
+var returningOverflowPattern = regexp.MustCompile(`RETURNING (\d+) rows`)
+
+// returningFields returns the fields of the RETURNING clause,
+// all the readable fields of the model are returned for RETURNING *
+func returningFields(stmt *gorm.Statement) (fields []*schema.Field, err error) {
+	if stmt.Schema == nil {
+		return nil, errors.New("RETURNING clause requires a model with schema for oracle")
+	}
+
+	returning, _ := stmt.Clauses["RETURNING"].Expression.(clause.Returning)
+	if len(returning.Columns) == 0 || (len(returning.Columns) == 1 && returning.Columns[0].Name == "*") {
+		for _, dbName := range stmt.Schema.DBNames {
+			if field := stmt.Schema.FieldsByDBName[dbName]; field != nil && field.Readable {
+				fields = append(fields, field)
+			}
+		}
+		return
+	}
+
+	for _, column := range returning.Columns {
+		field := stmt.Schema.LookUpField(column.Name)
+		if field == nil {
+			return nil, fmt.Errorf("failed to look up field with name: %s", column.Name)
+		}
+		fields = append(fields, field)
+	}
+	return
+}
+
+// returningArraySize returns the initial size of the arrays, it's the length of the updated or deleted slice
+// if it's longer than ReturningArraySize
+func returningArraySize(db *gorm.DB) int {
+	size := DefaultReturningArraySize
+	if d, ok := ptrDereference(db.Dialector).(Dialector); ok && d.Config != nil && d.ReturningArraySize > 0 {
+		size = d.ReturningArraySize
+	}
+	if rv := reflect.Indirect(db.Statement.ReflectValue); (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() > size {
+		size = rv.Len()
+	}
+	return size
+}
+
+// returningElemType returns the element type of the array out-bind of the field,
+// the values are bound as their basic types and set by field.Set, so gorm.DeletedAt and
+// the other Valuer/Scanner fields are supported without registering them in go-ora
+func returningElemType(field *schema.Field) reflect.Type {
+	switch field.DataType {
+	case schema.Bool:
+		return reflect.TypeOf(sql.NullBool{})
+	case schema.Int, schema.Uint:
+		return reflect.TypeOf(sql.NullInt64{})
+	case schema.Float:
+		return reflect.TypeOf(sql.NullFloat64{})
+	case schema.String:
+		return reflect.TypeOf(sql.NullString{})
+	case schema.Time:
+		return reflect.TypeOf(sql.NullTime{})
+	case schema.Bytes:
+		return reflect.TypeOf([]byte(nil))
+	default:
+		return field.FieldType
+	}
+}
+
+// buildReturningInto rewrite the UPDATE or DELETE statement with RETURNING clause into a PL/SQL block,
+// which collects the returned values of all the affected rows into array out-binds of size elements.
+// The block raises ORA-20999 with the number of rows if they don't fit, so the statement is rolled back
+// and could be executed again with larger arrays, see execReturning.
+//
+//	DECLARE TYPE R0 IS TABLE OF "table"."id"%TYPE INDEX BY PLS_INTEGER; O0 R0;
+//	BEGIN UPDATE "table" SET "name"=:1 WHERE "age" > :2 RETURNING "id" BULK COLLECT INTO O0;
+//	IF O0.COUNT > 1000 THEN RAISE_APPLICATION_ERROR(-20999, 'RETURNING ' || O0.COUNT || ' rows'); END IF;
+//	:3 := O0; END;
+func buildReturningInto(db *gorm.DB, fields []*schema.Field, size int) {
+	stmt := db.Statement
+	dmlSQL := stmt.SQL.String()
+	if idx := strings.Index(dmlSQL, returningMarker); idx >= 0 {
+		dmlSQL = dmlSQL[:idx]
+	}
+	dmlSQL = strings.TrimSpace(dmlSQL)
+
+	stmt.SQL.Reset()
+	_, _ = stmt.WriteString("DECLARE ")
+	for idx, field := range fields {
+		_, _ = fmt.Fprintf(&stmt.SQL, "TYPE R%d IS TABLE OF ", idx)
+		stmt.WriteQuoted(clause.Column{Table: stmt.Table, Name: field.DBName})
+		_, _ = fmt.Fprintf(&stmt.SQL, "%%TYPE INDEX BY PLS_INTEGER; O%d R%d; ", idx, idx)
+	}
+	_, _ = stmt.WriteString("BEGIN ")
+	_, _ = stmt.WriteString(dmlSQL)
+	_, _ = stmt.WriteString(" RETURNING ")
+	for idx, field := range fields {
+		if idx > 0 {
+			_ = stmt.WriteByte(',')
+		}
+		stmt.WriteQuoted(field.DBName)
+	}
+	_, _ = stmt.WriteString(" BULK COLLECT INTO ")
+	for idx := range fields {
+		if idx > 0 {
+			_ = stmt.WriteByte(',')
+		}
+		_, _ = stmt.WriteString("O" + strconv.Itoa(idx))
+	}
+	_, _ = fmt.Fprintf(&stmt.SQL, "; IF O0.COUNT > %d THEN RAISE_APPLICATION_ERROR(-%d, 'RETURNING ' || O0.COUNT || ' rows'); END IF; ",
+		size, returningOverflowErrCode)
+
+	for idx, field := range fields {
+		stmt.AddVar(stmt, go_ora.Out{
+			Dest: reflect.New(reflect.SliceOf(returningElemType(field))).Interface(),
+			Size: size,
+		})
+		_, _ = fmt.Fprintf(&stmt.SQL, " := O%d; ", idx)
+	}
+	_, _ = stmt.WriteString("END;")
+}
+
+// returningOverflow returns the number of rows reported by the ORA-20999 error of the block built by buildReturningInto
+func returningOverflow(err error) (rows int, ok bool) {
+	oraErr, isOracle := AsOracleError(err)
+	if !isOracle || oraErr.Code != returningOverflowErrCode {
+		return
+	}
+	if m := returningOverflowPattern.FindStringSubmatch(oraErr.Message); len(m) > 1 {
+		rows, err = strconv.Atoi(m[1])
+		ok = err == nil
+	}
+	return
+}
+
+// scanReturningInto set the values of the array out-binds built by buildReturningInto to dest,
+// in the same way as gorm.Scan does with the rows of a RETURNING result set
+func scanReturningInto(db *gorm.DB, fields []*schema.Field, dest reflect.Value, mode gorm.ScanMode) {
+	var outs []reflect.Value
+	for _, val := range db.Statement.Vars {
+		if out, ok := val.(go_ora.Out); ok {
+			outs = append(outs, reflect.Indirect(reflect.ValueOf(out.Dest)))
+		}
+	}
+	if len(outs) != len(fields) {
+		return
+	}
+
+	rows := 0
+	if len(outs) > 0 {
+		rows = outs[0].Len()
+	}
+	db.RowsAffected = int64(rows)
+
+	setValues := func(elem reflect.Value, row int) {
+		for idx, field := range fields {
+			_ = db.AddError(field.Set(db.Statement.Context, elem, outs[idx].Index(row).Interface()))
+		}
+	}
+
+	for dest.Kind() == reflect.Ptr {
+		if dest.IsNil() {
+			return
+		}
+		dest = dest.Elem()
+	}
+	switch dest.Kind() {
+	case reflect.Slice, reflect.Array:
+		elemType := dest.Type().Elem()
+		isPtr := elemType.Kind() == reflect.Ptr
+		if isPtr {
+			elemType = elemType.Elem()
+		}
+		if elemType != db.Statement.Schema.ModelType {
+			_ = db.AddError(fmt.Errorf("%w: unsupported returning destination %s", gorm.ErrInvalidData, dest.Type()))
+			return
+		}
+
+		if mode&gorm.ScanUpdate != 0 && dest.Len() > 0 {
+			for row := 0; row < rows && row < dest.Len(); row++ {
+				setValues(dest.Index(row), row)
+			}
+		} else if dest.Kind() == reflect.Slice {
+			dest.Set(reflect.MakeSlice(dest.Type(), 0, rows))
+			for row := 0; row < rows; row++ {
+				elem := reflect.New(elemType)
+				setValues(elem, row)
+				if isPtr {
+					dest.Set(reflect.Append(dest, elem))
+				} else {
+					dest.Set(reflect.Append(dest, elem.Elem()))
+				}
+			}
+		}
+	case reflect.Struct:
+		if dest.Type() != db.Statement.Schema.ModelType {
+			_ = db.AddError(fmt.Errorf("%w: unsupported returning destination %s", gorm.ErrInvalidData, dest.Type()))
+			return
+		}
+		if rows > 0 {
+			setValues(dest, 0)
+		}
+	default:
+	}
+}
+
+// execReturning executes the UPDATE or DELETE statement with RETURNING clause as a PL/SQL block,
+// and scans the returned values into dest
+func execReturning(db *gorm.DB, dest reflect.Value, mode gorm.ScanMode) {
+	fields, err := returningFields(db.Statement)
+	if db.AddError(err) != nil {
+		return
+	}
+
+	stmt := db.Statement
+	dmlSQL, vars := stmt.SQL.String(), stmt.Vars[:len(stmt.Vars):len(stmt.Vars)]
+	size := returningArraySize(db)
+	for attempt := 1; ; attempt++ {
+		stmt.SQL.Reset()
+		_, _ = stmt.WriteString(dmlSQL)
+		stmt.Vars = vars
+		buildReturningInto(db, fields, size)
+
+		_, err = stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
+		// 行数超过数组大小时语句已回滚，按实际行数重试
+		if rows, ok := returningOverflow(err); ok && attempt < maxReturningAttempts {
+			size = rows
+			continue
+		}
+		if db.AddError(err) == nil {
+			scanReturningInto(db, fields, dest, mode)
+		}
+		return
+	}
+}
@@ -0,0 +1,201 @@
+package oracle
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sijms/go-ora/v2/network"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+// testReturningTags is a Valuer/Scanner field stored as comma-separated string
+type testReturningTags []string
+
+func (tags testReturningTags) Value() (driver.Value, error) {
+	return strings.Join(tags, ","), nil
+}
+
+func (tags *testReturningTags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*tags = nil
+	case string:
+		*tags = strings.Split(v, ",")
+	default:
+		return errors.New("unsupported tags value")
+	}
+	return nil
+}
+
+type testReturningTask struct {
+	ID        uint64            `gorm:"primaryKey"`
+	Name      string            `gorm:"size:50"`
+	Tags      testReturningTags `gorm:"size:200"`
+	DeletedAt gorm.DeletedAt
+}
+
+func TestUpdateDeleteReturning(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	model := TestTableUser{}
+	migrator := db.Migrator()
+	if migrator.HasTable(model) {
+		if err = migrator.DropTable(model); err != nil {
+			t.Fatalf("DropTable() error = %v", err)
+		}
+	}
+	if err = migrator.AutoMigrate(model); err != nil {
+		t.Fatalf("AutoMigrate() error = %v", err)
+	}
+
+	data := []TestTableUser{
+		{UID: "U1", Name: "Lisa", Account: "lisa", UserType: 1},
+		{UID: "U2", Name: "Daniela", Account: "daniela", UserType: 1},
+		{UID: "U3", Name: "Tom", Account: "tom", UserType: 2},
+	}
+	if err = db.Create(&data).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	returning := clause.Returning{Columns: []clause.Column{{Name: "id"}, {Name: "uid"}, {Name: "user_type"}}}
+	t.Run("UpdateReturning", func(t *testing.T) {
+		var users []TestTableUser
+		tx := db.Model(&users).Clauses(returning).Where("user_type = ?", 1).Update("user_type", 3)
+		if err = tx.Error; err != nil {
+			t.Fatal(err)
+		}
+		if tx.RowsAffected != 2 || len(users) != 2 {
+			t.Fatalf("RowsAffected = %d, len(users) = %d, want 2", tx.RowsAffected, len(users))
+		}
+		for _, user := range users {
+			if user.ID == 0 || user.UID == "" || user.UserType != 3 {
+				t.Errorf("unexpected returned user: %+v", user)
+			}
+		}
+	})
+
+	t.Run("DeleteReturning", func(t *testing.T) {
+		var users []TestTableUser
+		tx := db.Clauses(returning).Where("user_type = ?", 3).Delete(&users)
+		if err = tx.Error; err != nil {
+			t.Fatal(err)
+		}
+		if tx.RowsAffected != 2 || len(users) != 2 {
+			t.Fatalf("RowsAffected = %d, len(users) = %d, want 2", tx.RowsAffected, len(users))
+		}
+		for _, user := range users {
+			if user.UID != "U1" && user.UID != "U2" {
+				t.Errorf("unexpected returned user: %+v", user)
+			}
+		}
+	})
+}
+
+func TestGoldenReturning(t *testing.T) {
+	// the arrays of 1 element overflow, the statement is executed again with the arrays of 2 elements
+	db, recorder := openRecorder(t, Config{ReturningArraySize: 1}, "19.0.0.0.0")
+	recorder.Script(`O0\.COUNT > 1 THEN`, oracletest.Result{
+		Err: &network.OracleError{ErrCode: 20999, ErrMsg: "ORA-20999: RETURNING 2 rows\nORA-06512: at line 1"},
+	})
+	deletedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	recorder.Script(`O0\.COUNT > 2 THEN`, oracletest.Result{Out: []interface{}{
+		[]sql.NullInt64{{Int64: 1, Valid: true}, {Int64: 2, Valid: true}},
+		[]sql.NullString{{String: "renamed", Valid: true}, {String: "renamed", Valid: true}},
+		[]sql.NullString{{String: "a,b", Valid: true}, {}},
+		[]sql.NullTime{{}, {Time: deletedAt, Valid: true}},
+	}})
+
+	var tasks []testReturningTask
+	tx := db.Model(&tasks).Clauses(clause.Returning{}).Where("name = ?", "task").Update("name", "renamed")
+	if err := tx.Error; err != nil {
+		t.Fatal(err)
+	}
+	want := []testReturningTask{
+		{ID: 1, Name: "renamed", Tags: testReturningTags{"a", "b"}},
+		{ID: 2, Name: "renamed", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+	}
+	if tx.RowsAffected != 2 || len(tasks) != len(want) {
+		t.Fatalf("RowsAffected = %d, tasks = %+v, want %+v", tx.RowsAffected, tasks, want)
+	}
+	for i := range want {
+		if got := tasks[i]; got.ID != want[i].ID || got.Name != want[i].Name ||
+			strings.Join(got.Tags, ",") != strings.Join(want[i].Tags, ",") || got.DeletedAt != want[i].DeletedAt {
+			t.Errorf("tasks[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+	assertGolden(t, recorder)
+}
+
+func TestReturningOverflow(t *testing.T) {
+	db, recorder := openRecorder(t, Config{ReturningArraySize: 1}, "19.0.0.0.0")
+	// the number of rows keeps growing between the executions
+	for size := 1; size <= maxReturningAttempts; size++ {
+		recorder.Script(`O0\.COUNT > `+strconv.Itoa(size)+` THEN`, oracletest.Result{
+			Err: &network.OracleError{ErrCode: 20999, ErrMsg: "ORA-20999: RETURNING " + strconv.Itoa(size+1) + " rows"},
+		})
+	}
+
+	var tasks []testReturningTask
+	err := db.Model(&tasks).Clauses(clause.Returning{}).Where("name = ?", "task").Update("name", "renamed").Error
+	if rows, ok := returningOverflow(err); !ok || rows != maxReturningAttempts+1 {
+		t.Errorf("Update() error = %v, want the overflow of %d rows", err, maxReturningAttempts+1)
+	}
+	if got := len(recorder.Statements()); got != maxReturningAttempts {
+		t.Errorf("executed %d statements, want %d", got, maxReturningAttempts)
+	}
+}
+
+func TestReturningAll(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	if err = db.Migrator().AutoMigrate(&testReturningTask{}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = db.Migrator().DropTable(&testReturningTask{})
+	}()
+
+	// more rows than DefaultReturningArraySize
+	data := make([]testReturningTask, DefaultReturningArraySize+1)
+	for i := range data {
+		data[i] = testReturningTask{ID: uint64(i + 1), Name: "task", Tags: testReturningTags{"a", "b"}}
+	}
+	if err = db.CreateInBatches(&data, 500).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var tasks []testReturningTask
+	tx := db.Clauses(clause.Returning{}).Where("1 = 1").Delete(&tasks)
+	if err = tx.Error; err != nil {
+		t.Fatal(err)
+	}
+	if tx.RowsAffected != int64(len(data)) || len(tasks) != len(data) {
+		t.Fatalf("RowsAffected = %d, len(tasks) = %d, want %d", tx.RowsAffected, len(tasks), len(data))
+	}
+	for _, task := range tasks {
+		if task.ID == 0 || strings.Join(task.Tags, ",") != "a,b" || !task.DeletedAt.Valid {
+			t.Errorf("unexpected returned task: %+v", task)
+			break
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package oracle
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// SequenceOf returns the sequence that generates values of the field,
+// which is specified with the `sequence:NAME` tag, or generated for auto-increment fields on Oracle 11g
+//
+//	type User struct {
+//		ID uint64 `gorm:"primaryKey;sequence:USER_ID_SEQ"`
+//	}
+//
+// The sequence tag should be used on fields with default database value, e.g. integer primary keys,
+// auto-increment fields or fields tagged with `default:null`, so that the values are omitted and returned by Create
+func (d Dialector) SequenceOf(field *schema.Field) (name string, ok bool) {
+	if field == nil {
+		return
+	}
+	if name = strings.TrimSpace(field.TagSettings["SEQUENCE"]); name != "" {
+		return d.identifierName(name), true
+	}
+	if field.AutoIncrement && !d.capabilities().IdentityColumns && field.Schema != nil {
+		return d.generatedObjectName("SEQ_", field.Schema.Table, field.DBName), true
+	}
+	return "", false
+}
+
+// sequenceTriggerName returns the name of the trigger that fills the field with sequence values on Oracle 11g
+func (d Dialector) sequenceTriggerName(field *schema.Field) string {
+	return d.generatedObjectName("TRG_", field.Schema.Table, field.DBName)
+}
+
+// generatedObjectName generates the name of a database object of table column,
+// the name is shortened with hash when it exceeds the identifier limit of the database
+func (d Dialector) generatedObjectName(prefix, table, column string) string {
+	maxLength := d.capabilities().MaxIdentifierLength()
+	var owner string
+	if idx := strings.LastIndex(table, "."); idx >= 0 {
+		owner, table = table[:idx+1], table[idx+1:]
+	}
+	name := strings.ReplaceAll(prefix+table+"_"+column, `"`, "")
+	if len(name) > maxLength {
+		h := sha1.New()
+		_, _ = h.Write([]byte(name))
+		bs := h.Sum(nil)
+		name = name[:maxLength-8] + hex.EncodeToString(bs)[:8]
+	}
+	return d.identifierName(owner + name)
+}
+
+// identifierName returns appropriate capitalization name based on NamingCaseSensitive
+func (d Dialector) identifierName(name string) string {
+	if d.Config != nil && d.NamingCaseSensitive {
+		return name
+	}
+	return strings.ToUpper(name)
+}
+
+// quoteName returns the quoted name of database object
+func (d Dialector) quoteName(name string) string {
+	var builder strings.Builder
+	d.QuoteTo(&builder, name)
+	return builder.String()
+}
+
+// hasSequence check has sequence "name" or not
+func (m Migrator) hasSequence(name string) bool {
+	var count int64
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		_ = m.DB.Raw(
+			"SELECT COUNT(*) FROM ALL_SEQUENCES WHERE SEQUENCE_OWNER = ? AND SEQUENCE_NAME = ?",
+			name[:idx], name[idx+1:],
+		).Row().Scan(&count)
+	} else {
+		_ = m.DB.Raw("SELECT COUNT(*) FROM USER_SEQUENCES WHERE SEQUENCE_NAME = ?", name).Row().Scan(&count)
+	}
+	return count > 0
+}
+
+// createSequences create the missing sequences of the fields before creating table or adding columns,
+// since the column defaults of Oracle 12c+ refer to the sequences
+//
+//goland:noinspection SqlNoDataSourceInspection
+func (m Migrator) createSequences(fields ...*schema.Field) (err error) {
+	d := m.Dialector.(Dialector)
+	for _, field := range fields {
+		if name, ok := d.SequenceOf(field); ok && !m.hasSequence(name) {
+			createSQL := "CREATE SEQUENCE "
+			if d.capabilities().IfExists {
+				createSQL += "IF NOT EXISTS "
+			}
+			if err = m.DB.Exec(createSQL + d.quoteName(name) + " START WITH 1 INCREMENT BY 1 NOCACHE").Error; err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// createSequenceTriggers create the triggers filling the fields with sequence values on Oracle 11g
+//
+//goland:noinspection SqlNoDataSourceInspection
+func (m Migrator) createSequenceTriggers(stmt *gorm.Statement, fields ...*schema.Field) (err error) {
+	d := m.Dialector.(Dialector)
+	if d.capabilities().IdentityColumns {
+		return
+	}
+	for _, field := range fields {
+		name, ok := d.SequenceOf(field)
+		if !ok {
+			continue
+		}
+		column := d.quoteName(field.DBName)
+		if err = m.DB.Exec(
+			"CREATE OR REPLACE TRIGGER " + d.quoteName(d.sequenceTriggerName(field)) +
+				" BEFORE INSERT ON " + d.quoteName(stmt.Table) + " FOR EACH ROW WHEN (NEW." + column + " IS NULL)" +
+				" BEGIN SELECT " + d.quoteName(name) + ".NEXTVAL INTO :NEW." + column + " FROM " + d.DummyTableName() + "; END;",
+		).Error; err != nil {
+			return
+		}
+	}
+	return
+}
+
+// dropSequences drop the generated sequences of auto-increment fields on Oracle 11g,
+// the sequences specified with tag may be shared and are kept
+//
+//goland:noinspection SqlNoDataSourceInspection
+func (m Migrator) dropSequences(fields ...*schema.Field) (err error) {
+	d := m.Dialector.(Dialector)
+	for _, field := range fields {
+		if field.TagSettings["SEQUENCE"] != "" {
+			continue
+		}
+		if name, ok := d.SequenceOf(field); ok && m.hasSequence(name) {
+			if err = m.DB.Exec("DROP SEQUENCE " + d.quoteName(name)).Error; err != nil {
+				return
+			}
+		}
+	}
+	return
+}
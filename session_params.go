@@ -0,0 +1,181 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+)
+
+// SessionParam is a session parameter set by ALTER SESSION on every new connection, see Config.SessionParams
+type SessionParam struct {
+	Name  string
+	Value string
+	// Raw is true if Value is written as it is, e.g. a keyword like LINGUISTIC,
+	// otherwise Value is quoted as a string literal
+	Raw bool
+}
+
+// SQL returns the ALTER SESSION statement of the parameter
+func (p SessionParam) SQL() string {
+	value := p.Value
+	if !p.Raw {
+		value = GetStringExpr(value, true).SQL
+	}
+	return fmt.Sprintf("ALTER SESSION SET %s = %s", p.Name, value)
+}
+
+// SessionParamError is the error of the session parameter which failed to be set on a new connection
+type SessionParamError struct {
+	Param SessionParam
+	Err   error
+}
+
+func (e *SessionParamError) Error() string {
+	return fmt.Sprintf("failed to set session parameter %s: %v", e.Param.Name, e.Err)
+}
+
+func (e *SessionParamError) Unwrap() error {
+	return e.Err
+}
+
+// errSessionParamsConn is the error of Config.SessionParams that can't be set on the pool of Config.Conn
+var errSessionParamsConn = errors.New("the pool of Config.Conn must be opened by oracle.WithSessionParams to set them")
+
+// WithSessionParams returns the connector setting the session parameters in order on every new connection
+// opened by connector, including the connections opened again after the broken ones are discarded by the pool.
+// A connection fails with *SessionParamError if any parameter fails. The isolation level and read-only option of
+// the transactions begun on the connections are mapped to SET TRANSACTION, see TxOptions.
+// It's used to build the pool of Config.Conn, e.g.
+//
+//	sqlDB := sql.OpenDB(oracle.WithSessionParams(go_ora.NewConnector(dsn),
+//		oracle.SessionParam{Name: "TIME_ZONE", Value: "+08:00"},
+//		oracle.SessionParam{Name: "NLS_SORT", Value: "BINARY_CI", Raw: true},
+//	))
+//	db, err := gorm.Open(oracle.New(oracle.Config{Conn: sqlDB}), &gorm.Config{})
+func WithSessionParams(connector driver.Connector, params ...SessionParam) driver.Connector {
+	return &sessionConnector{Connector: connector, params: params, mapTxOptions: true}
+}
+
+// sessionConnector sets the session parameters on the connections opened by Connector
+type sessionConnector struct {
+	driver.Connector
+	// optional are set before params, and their errors are ignored, like the NLS parameters of IgnoreCase
+	optional []SessionParam
+	params   []SessionParam
+	// mapTxOptions wraps the connections by txOptionsConn, otherwise they're returned as they are
+	mapTxOptions bool
+}
+
+// Connect implements driver.Connector
+func (c *sessionConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, param := range c.optional {
+		_ = execDriverConn(ctx, conn, param.SQL())
+	}
+	for _, param := range c.params {
+		if err = execDriverConn(ctx, conn, param.SQL()); err != nil {
+			_ = conn.Close()
+			return nil, &SessionParamError{Param: param, Err: err}
+		}
+	}
+	if c.mapTxOptions {
+		return &txOptionsConn{Conn: conn}, nil
+	}
+	return conn, nil
+}
+
+// Close closes the connector if it's an io.Closer, it's called by sql.DB.Close
+func (c *sessionConnector) Close() error {
+	if closer, ok := c.Connector.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// execDriverConn executes the statement without arguments on the driver connection
+func execDriverConn(ctx context.Context, conn driver.Conn, query string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		if _, err := execer.ExecContext(ctx, query, nil); err != driver.ErrSkip {
+			return err
+		}
+	}
+	stmt, err := conn.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = stmt.Close()
+	}()
+	if execer, ok := stmt.(driver.StmtExecContext); ok {
+		_, err = execer.ExecContext(ctx, nil)
+	} else {
+		//goland:noinspection GoDeprecation
+		_, err = stmt.Exec(nil)
+	}
+	return err
+}
+
+// ignoreCaseParams returns the NLS parameters of IgnoreCase, their errors are ignored like before
+// they were set on every new connection
+func (d Dialector) ignoreCaseParams() []SessionParam {
+	if !d.IgnoreCase {
+		return nil
+	}
+	// warning: may cause performance issues
+	return []SessionParam{
+		{Name: "NLS_COMP", Value: "LINGUISTIC", Raw: true},
+		{Name: "NLS_SORT", Value: "BINARY_CI", Raw: true},
+	}
+}
+
+// openDB opens the pool of DSN, it's opened by the connector of WithSessionParams if the session parameters
+// are set on every new connection, or the options of the transactions are mapped, see Config.MapTxOptions
+func (d Dialector) openDB() (*sql.DB, error) {
+	sqlDB, err := sql.Open(d.DriverName, d.DSN)
+	optional := d.ignoreCaseParams()
+	if err != nil || len(optional)+len(d.SessionParams) == 0 && !d.MapTxOptions {
+		return sqlDB, err
+	}
+	drv, ok := sqlDB.Driver().(driver.DriverContext)
+	if !ok {
+		_ = sqlDB.Close()
+		return nil, fmt.Errorf("the driver %s doesn't support the session parameters", d.DriverName)
+	}
+	connector, err := drv.OpenConnector(d.DSN)
+	if err != nil {
+		return nil, err
+	}
+	_ = sqlDB.Close()
+	return sql.OpenDB(&sessionConnector{
+		Connector:    connector,
+		optional:     optional,
+		params:       d.SessionParams,
+		mapTxOptions: d.MapTxOptions,
+	}), nil
+}
+
+// setSessionParams checks the session parameters of the pool of Config.Conn, which is opened by the user.
+// Config.SessionParams fail with *SessionParamError since they can't be set on every new connection of it,
+// the NLS parameters of IgnoreCase are registered to go-ora as before.
+func (d Dialector) setSessionParams(db *gorm.DB) error {
+	if len(d.SessionParams) > 0 {
+		return &SessionParamError{Param: d.SessionParams[0], Err: errSessionParamsConn}
+	}
+	if sqlDB, ok := db.ConnPool.(*sql.DB); ok && d.IgnoreCase {
+		if _, ok = sqlDB.Driver().(*go_ora.OracleDriver); ok {
+			for _, param := range d.ignoreCaseParams() {
+				_ = go_ora.AddSessionParam(sqlDB, param.Name, param.Value)
+			}
+		}
+	}
+	return nil
+}
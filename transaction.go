@@ -0,0 +1,208 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnsupportedIsolation is returned by Begin and Transaction for the isolation levels Oracle doesn't support
+var ErrUnsupportedIsolation = errors.New("unsupported transaction isolation level")
+
+// TxOptions are the options of the transactions begun by Begin and Transaction.
+// The isolation level and read-only option of sql.TxOptions passed to db.Begin and db.Transaction
+// are mapped in the same way for the pools opened by WithSessionParams, or from DSN with Config.MapTxOptions,
+// go-ora rejects them for the other pools.
+type TxOptions struct {
+	// Isolation is mapped to the levels supported by Oracle:
+	//
+	//	LevelDefault          the default level of the session, READ COMMITTED unless it's altered
+	//	LevelReadCommitted    READ COMMITTED
+	//	LevelSerializable     SERIALIZABLE
+	//
+	// the other levels have no exact equivalent, they're rejected with ErrUnsupportedIsolation
+	Isolation sql.IsolationLevel
+	// ReadOnly begins the transaction by SET TRANSACTION READ ONLY,
+	// whose queries see the data committed before it like SERIALIZABLE,
+	// so it's rejected with ErrUnsupportedIsolation for LevelReadCommitted
+	ReadOnly bool
+	// Name is the name of the transaction, shown in V$TRANSACTION
+	Name string
+}
+
+// setTransactionSQL returns the SET TRANSACTION statement of the options,
+// it's empty if the transaction is begun with the default options
+func (opts TxOptions) setTransactionSQL() (string, error) {
+	var builder strings.Builder
+	switch opts.Isolation {
+	case sql.LevelDefault:
+	case sql.LevelReadCommitted:
+		if opts.ReadOnly {
+			// the queries of read-only transactions see the data committed before them, not READ COMMITTED
+			return "", fmt.Errorf("%w: read-only %s, Oracle supports read-only transactions of SERIALIZABLE",
+				ErrUnsupportedIsolation, opts.Isolation)
+		}
+		builder.WriteString("SET TRANSACTION ISOLATION LEVEL READ COMMITTED")
+	case sql.LevelSerializable:
+		if !opts.ReadOnly {
+			builder.WriteString("SET TRANSACTION ISOLATION LEVEL SERIALIZABLE")
+		}
+	default:
+		return "", fmt.Errorf("%w: %s, Oracle supports READ COMMITTED and SERIALIZABLE", ErrUnsupportedIsolation, opts.Isolation)
+	}
+	if opts.ReadOnly {
+		builder.WriteString("SET TRANSACTION READ ONLY")
+	}
+	if opts.Name != "" {
+		if builder.Len() == 0 {
+			builder.WriteString("SET TRANSACTION")
+		}
+		builder.WriteString(" NAME ")
+		builder.WriteString(GetStringExpr(opts.Name, true).SQL)
+	}
+	return builder.String(), nil
+}
+
+// Begin begins a transaction with the options, SET TRANSACTION is executed as the first statement of it, e.g.
+//
+//	tx := oracle.Begin(db, oracle.TxOptions{Isolation: sql.LevelSerializable, Name: "transfer"})
+//	// SET TRANSACTION ISOLATION LEVEL SERIALIZABLE NAME 'transfer'
+//
+// The serializable transactions fail with ORA-08177 if the rows they change were changed by others after
+// they began, see ErrSerializationFailure.
+func Begin(db *gorm.DB, opts TxOptions) *gorm.DB {
+	setTransaction, err := opts.setTransactionSQL()
+	if err != nil {
+		return addCallError(db, err)
+	}
+	tx := db.Begin()
+	if tx.Error != nil || setTransaction == "" {
+		return tx
+	}
+	// SET TRANSACTION must be the first statement of the transaction,
+	// so the client information and DBMS_OUTPUT aren't set before it
+	ctx := context.WithValue(tx.Statement.Context, clientInfoContextKey{}, nil)
+	if err = tx.Session(&gorm.Session{Context: ctx}).Set(DBMSOutputKey, false).Exec(setTransaction).Error; err != nil {
+		tx.Rollback()
+		_ = tx.AddError(err)
+	}
+	return tx
+}
+
+// Transaction executes fc in a transaction begun by Begin with the options, it's committed if fc returns nil,
+// otherwise it's rolled back, e.g.
+//
+//	err := oracle.Transaction(db, func(tx *gorm.DB) error {
+//		return tx.Model(&account).Update("balance", gorm.Expr("balance - ?", amount)).Error
+//	}, oracle.TxOptions{Isolation: sql.LevelSerializable})
+//	if errors.Is(err, oracle.ErrSerializationFailure) {
+//		// retry
+//	}
+func Transaction(db *gorm.DB, fc func(tx *gorm.DB) error, opts TxOptions) (err error) {
+	tx := Begin(db, opts)
+	if err = tx.Error; err != nil {
+		return
+	}
+
+	panicked := true
+	defer func() {
+		// 出错或 panic 时回滚
+		if panicked || err != nil {
+			tx.Rollback()
+		}
+	}()
+	if err = fc(tx); err == nil {
+		err = tx.Commit().Error
+	}
+	panicked = false
+	return
+}
+
+// txOptionsConn is the driver connection opened by the connectors of WithSessionParams and Config.MapTxOptions,
+// it maps the options of BeginTx to SET TRANSACTION like TxOptions, since go-ora rejects them
+type txOptionsConn struct {
+	driver.Conn
+}
+
+// BeginTx implements driver.ConnBeginTx
+func (c *txOptionsConn) BeginTx(ctx context.Context, opts driver.TxOptions) (tx driver.Tx, err error) {
+	setTransaction, err := TxOptions{Isolation: sql.IsolationLevel(opts.Isolation), ReadOnly: opts.ReadOnly}.setTransactionSQL()
+	if err != nil {
+		return nil, err
+	}
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = beginner.BeginTx(ctx, driver.TxOptions{})
+	} else {
+		//goland:noinspection GoDeprecation
+		tx, err = c.Conn.Begin()
+	}
+	if err != nil || setTransaction == "" {
+		return
+	}
+	if err = execDriverConn(ctx, c.Conn, setTransaction); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return
+}
+
+// PrepareContext implements driver.ConnPrepareContext
+func (c *txOptionsConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+// ExecContext implements driver.ExecerContext, database/sql prepares the statement if it returns driver.ErrSkip
+func (c *txOptionsConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := c.Conn.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// QueryContext implements driver.QueryerContext, database/sql prepares the statement if it returns driver.ErrSkip
+func (c *txOptionsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := c.Conn.(driver.QueryerContext); ok {
+		return queryer.QueryContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+// CheckNamedValue implements driver.NamedValueChecker, database/sql converts the value if it returns driver.ErrSkip
+func (c *txOptionsConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// Ping implements driver.Pinger
+func (c *txOptionsConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// ResetSession implements driver.SessionResetter
+func (c *txOptionsConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+// IsValid implements driver.Validator
+func (c *txOptionsConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
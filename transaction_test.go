@@ -0,0 +1,197 @@
+package oracle
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/sijms/go-ora/v2/network"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+func TestTxOptionsSetTransactionSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    TxOptions
+		want    string
+		wantErr error
+	}{
+		{name: "Default", opts: TxOptions{}},
+		{name: "ReadCommitted", opts: TxOptions{Isolation: sql.LevelReadCommitted}, want: "SET TRANSACTION ISOLATION LEVEL READ COMMITTED"},
+		{name: "Serializable", opts: TxOptions{Isolation: sql.LevelSerializable}, want: "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE"},
+		{name: "ReadOnly", opts: TxOptions{ReadOnly: true}, want: "SET TRANSACTION READ ONLY"},
+		{name: "ReadOnlySerializable", opts: TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}, want: "SET TRANSACTION READ ONLY"},
+		{name: "ReadOnlyReadCommitted", opts: TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: true}, wantErr: ErrUnsupportedIsolation},
+		{name: "Named", opts: TxOptions{Name: "transfer"}, want: "SET TRANSACTION NAME 'transfer'"},
+		{name: "NamedSerializable", opts: TxOptions{Isolation: sql.LevelSerializable, Name: "it's"},
+			want: "SET TRANSACTION ISOLATION LEVEL SERIALIZABLE NAME q'[it's]'"},
+		{name: "ReadUncommitted", opts: TxOptions{Isolation: sql.LevelReadUncommitted}, wantErr: ErrUnsupportedIsolation},
+		{name: "RepeatableRead", opts: TxOptions{Isolation: sql.LevelRepeatableRead}, wantErr: ErrUnsupportedIsolation},
+		{name: "Snapshot", opts: TxOptions{Isolation: sql.LevelSnapshot}, wantErr: ErrUnsupportedIsolation},
+		{name: "WriteCommitted", opts: TxOptions{Isolation: sql.LevelWriteCommitted}, wantErr: ErrUnsupportedIsolation},
+		{name: "Linearizable", opts: TxOptions{Isolation: sql.LevelLinearizable}, wantErr: ErrUnsupportedIsolation},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.setTransactionSQL()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("setTransactionSQL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("setTransactionSQL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoldenTransaction(t *testing.T) {
+	db, recorder := openRecorder(t, Config{}, "19.0.0.0.0")
+
+	t.Run("Serializable", func(t *testing.T) {
+		recorder.Reset()
+		if err := Transaction(db, func(tx *gorm.DB) error {
+			return tx.Exec(`UPDATE ACCOUNTS SET BALANCE = BALANCE - ? WHERE ID = ?`, 100, 1).Error
+		}, TxOptions{Isolation: sql.LevelSerializable, Name: "transfer"}); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		recorder.Reset()
+		tx := Begin(db, TxOptions{ReadOnly: true})
+		if tx.Error != nil {
+			t.Fatal(tx.Error)
+		}
+		var total int
+		if err := tx.Raw(`SELECT COUNT(*) FROM ACCOUNTS`).Scan(&total).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Commit().Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		recorder.Reset()
+		errCancel := errors.New("cancel")
+		if err := Transaction(db, func(tx *gorm.DB) error {
+			return errCancel
+		}, TxOptions{Isolation: sql.LevelReadCommitted}); !errors.Is(err, errCancel) {
+			t.Fatalf("Transaction() error = %v, want %v", err, errCancel)
+		}
+		assertGolden(t, recorder)
+	})
+}
+
+func TestGoldenTransactionOptions(t *testing.T) {
+	recorder := oracletest.NewRecorder()
+	recorder.Version = "19.0.0.0.0"
+	db, err := gorm.Open(New(Config{Conn: sql.OpenDB(WithSessionParams(recorder.Connector()))}), &gorm.Config{
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Begin", func(t *testing.T) {
+		recorder.Reset()
+		tx := db.Begin(&sql.TxOptions{Isolation: sql.LevelSerializable})
+		if tx.Error != nil {
+			t.Fatal(tx.Error)
+		}
+		if err := tx.Exec(`UPDATE ACCOUNTS SET BALANCE = BALANCE - ? WHERE ID = ?`, 100, 1).Error; err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Commit().Error; err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		recorder.Reset()
+		var total int
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			return tx.Raw(`SELECT COUNT(*) FROM ACCOUNTS`).Scan(&total).Error
+		}, &sql.TxOptions{ReadOnly: true}); err != nil {
+			t.Fatal(err)
+		}
+		assertGolden(t, recorder)
+	})
+
+	for _, opts := range []*sql.TxOptions{
+		{Isolation: sql.LevelRepeatableRead},
+		{Isolation: sql.LevelReadCommitted, ReadOnly: true},
+	} {
+		if err := db.Begin(opts).Error; !errors.Is(err, ErrUnsupportedIsolation) {
+			t.Errorf("db.Begin(%+v) error = %v, want %v", *opts, err, ErrUnsupportedIsolation)
+		}
+	}
+}
+
+func TestTransactionErrors(t *testing.T) {
+	db, recorder := openRecorder(t, Config{}, "19.0.0.0.0")
+
+	// go-ora rejects the non-default options of db.Begin unless the pool is opened by WithSessionParams
+	if err := db.Begin(&sql.TxOptions{Isolation: sql.LevelSerializable}).Error; err == nil {
+		t.Error("db.Begin() with isolation level returned no error")
+	}
+
+	if err := Begin(db, TxOptions{Isolation: sql.LevelLinearizable}).Error; !errors.Is(err, ErrUnsupportedIsolation) {
+		t.Errorf("Begin() error = %v, want %v", err, ErrUnsupportedIsolation)
+	}
+	if got := recorder.Statements(); len(got) != 0 {
+		t.Errorf("the rejected transaction executed statements: %v", got)
+	}
+
+	// ORA-08177 is returned by the statements changing the rows changed by others after the transaction began
+	db.Config.TranslateError = true
+	recorder.Script(`^UPDATE ACCOUNTS`, oracletest.Result{
+		Err: &network.OracleError{ErrCode: 8177, ErrMsg: "ORA-08177: can't serialize access for this transaction"},
+	})
+	err := Transaction(db, func(tx *gorm.DB) error {
+		return tx.Exec(`UPDATE ACCOUNTS SET BALANCE = 0 WHERE ID = ?`, 1).Error
+	}, TxOptions{Isolation: sql.LevelSerializable})
+	if !errors.Is(err, ErrSerializationFailure) {
+		t.Errorf("Transaction() error = %v, want %v", err, ErrSerializationFailure)
+	}
+	if statements := recorder.Statements(); statements[len(statements)-1].SQL != "ROLLBACK" {
+		t.Errorf("the failed transaction is not rolled back: %v", statements)
+	}
+}
+
+func TestSerializableTransaction(t *testing.T) {
+	db, err := dbNamingCase, dbErrors[0]
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db == nil {
+		t.Skip("db is nil!")
+	}
+
+	if err = db.Migrator().AutoMigrate(&testClientInfoOrder{}); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = db.Migrator().DropTable(&testClientInfoOrder{})
+	}()
+
+	if err = Transaction(db, func(tx *gorm.DB) error {
+		return tx.Create(&testClientInfoOrder{ID: 1, Status: "NEW"}).Error
+	}, TxOptions{Isolation: sql.LevelSerializable, Name: "gorm-oracle"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// ORA-01456: may not perform insert/delete/update operation inside a READ ONLY transaction
+	if err = Transaction(db, func(tx *gorm.DB) error {
+		return tx.Create(&testClientInfoOrder{ID: 2, Status: "NEW"}).Error
+	}, TxOptions{ReadOnly: true}); err == nil {
+		t.Error("the insert in a read-only transaction returned no error")
+	}
+}
@@ -35,6 +35,7 @@ func Update(config *callbacks.Config) func(db *gorm.DB) {
 			stmt.AddClauseIfNotExists(clause.Update{})
 			if _, ok := stmt.Clauses["SET"]; !ok {
 				if set := ConvertToAssignments(stmt); len(set) != 0 {
+					convertObjectAssignments(stmt.Schema, set)
 					defer delete(stmt.Clauses, "SET")
 					stmt.AddClause(set)
 				} else {
@@ -48,18 +49,13 @@ func Update(config *callbacks.Config) func(db *gorm.DB) {
 		checkMissingWhereConditions(db)
 
 		if !db.DryRun && db.Error == nil {
+			nativeBoolean := getNativeBoolean(db)
 			for i, val := range stmt.Vars {
 				// HACK: replace values one by one, assuming its value layout will be the same all the time, i.e. aligned
-				stmt.Vars[i] = convertValue(val)
+				stmt.Vars[i] = convertValue(val, nativeBoolean)
 			}
 			if ok, mode := hasReturning(db, supportReturning); ok {
-				if rows, err := stmt.ConnPool.QueryContext(stmt.Context, stmt.SQL.String(), stmt.Vars...); db.AddError(err) == nil {
-					dest := stmt.Dest
-					stmt.Dest = stmt.ReflectValue.Addr().Interface()
-					gorm.Scan(rows, db, mode)
-					stmt.Dest = dest
-					_ = db.AddError(rows.Close())
-				}
+				execReturning(db, stmt.ReflectValue, mode)
 			} else {
 				result, err := stmt.ConnPool.ExecContext(stmt.Context, stmt.SQL.String(), stmt.Vars...)
 
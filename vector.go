@@ -0,0 +1,273 @@
+package oracle
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sijms/go-ora/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// VectorDataType is the gorm data type of VECTOR fields of Oracle 23ai,
+// it's mapped to VECTOR(n, FORMAT) with the dimension of size tag and the format of element type
+//
+//	type Document struct {
+//		ID        uint64
+//		Embedding oracle.Vector[float32] `gorm:"size:768"` // VECTOR(768, FLOAT32)
+//	}
+const VectorDataType schema.DataType = "vector"
+
+// ErrVectorUnsupported is returned by the migrator for the VECTOR columns and indexes
+// when the server or its COMPATIBLE parameter is older than 23ai, see Capabilities.Vector
+var ErrVectorUnsupported = errors.New("VECTOR data type requires Oracle 23ai with COMPATIBLE 23 or later")
+
+// VectorElement is the element type of Vector, which is FLOAT32, FLOAT64 or INT8 format
+type VectorElement interface {
+	float32 | float64 | int8
+}
+
+// Vector defined VECTOR data type of Oracle 23ai, need to implement driver.Valuer, sql.Scanner interface
+type Vector[T VectorElement] []T
+
+// vectorValuer is implemented by Vector to bind the binary VECTOR value of go-ora
+type vectorValuer interface {
+	oracleVector() (interface{}, error)
+}
+
+// Value return the text of vector, implement driver.Valuer interface,
+// the statements of gorm bind the binary VECTOR value instead, see GormValue
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v Vector[T]) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
+// Scan value into Vector, implements sql.Scanner interface
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v *Vector[T]) Scan(val interface{}) (err error) {
+	switch data := val.(type) {
+	case nil:
+		*v = nil
+	case go_ora.Vector:
+		return v.Scan(data.Data)
+	case *go_ora.Vector:
+		if data == nil {
+			*v = nil
+			return
+		}
+		return v.Scan(data.Data)
+	case []float32:
+		*v = convertVector[T](data)
+	case []float64:
+		*v = convertVector[T](data)
+	case []uint8:
+		// INT8 vector is returned as []uint8 by go-ora, the bytes are signed
+		*v = convertVector[T]([]int8(convertVector[int8](data)))
+	case string:
+		var values []float64
+		if err = json.Unmarshal([]byte(data), &values); err != nil {
+			return
+		}
+		*v = convertVector[T](values)
+	default:
+		return errors.New(fmt.Sprint("Failed to scan VECTOR value:", val))
+	}
+	return
+}
+
+func convertVector[T VectorElement, S float32 | float64 | uint8 | int8](values []S) Vector[T] {
+	if values == nil {
+		return nil
+	}
+	result := make(Vector[T], len(values))
+	for idx, value := range values {
+		result[idx] = T(value)
+	}
+	return result
+}
+
+// String returns the text of vector, e.g. [1.5,2,-3]
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v Vector[T]) String() string {
+	var builder strings.Builder
+	_ = builder.WriteByte('[')
+	for idx, value := range v {
+		if idx > 0 {
+			_ = builder.WriteByte(',')
+		}
+		switch element := any(value).(type) {
+		case float32:
+			_, _ = builder.WriteString(strconv.FormatFloat(float64(element), 'g', -1, 32))
+		case float64:
+			_, _ = builder.WriteString(strconv.FormatFloat(element, 'g', -1, 64))
+		case int8:
+			_, _ = builder.WriteString(strconv.Itoa(int(element)))
+		}
+	}
+	_ = builder.WriteByte(']')
+	return builder.String()
+}
+
+// GormDataType gorm common data type
+//
+//goland:noinspection GoMixedReceiverTypes
+func (Vector[T]) GormDataType() string {
+	return string(VectorDataType)
+}
+
+//goland:noinspection GoMixedReceiverTypes
+func (v Vector[T]) GormValue(_ context.Context, _ *gorm.DB) clause.Expr {
+	value, err := v.oracleVector()
+	if err != nil {
+		value = v.String()
+	}
+	return gorm.Expr("?", value)
+}
+
+// oracleVector returns the VECTOR value of go-ora, or nil for nil vector
+//
+//goland:noinspection GoMixedReceiverTypes
+func (v Vector[T]) oracleVector() (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var data interface{} = []T(v)
+	if values, ok := data.([]int8); ok {
+		// go-ora binds INT8 vector with []uint8
+		bytes := make([]uint8, len(values))
+		for idx, value := range values {
+			bytes[idx] = uint8(value)
+		}
+		data = bytes
+	}
+	vector, err := go_ora.NewVector(data)
+	if err != nil {
+		return nil, err
+	}
+	return *vector, nil
+}
+
+// vectorText returns the text of VECTOR value of go-ora
+func vectorText(vector go_ora.Vector) string {
+	switch data := vector.Data.(type) {
+	case []float32:
+		return Vector[float32](data).String()
+	case []float64:
+		return Vector[float64](data).String()
+	case []uint8:
+		return convertVector[int8](data).String()
+	default:
+		return "[]"
+	}
+}
+
+// vectorDataTypeOf returns VECTOR(n, FORMAT) of the field, the dimension is the size of field,
+// and the format is decided by the element type of field, both are flexible (*) if they're unknown
+func vectorDataTypeOf(field *schema.Field) string {
+	dimension, format := "*", "*"
+	if field.Size > 0 {
+		dimension = strconv.Itoa(field.Size)
+	}
+	if t := field.IndirectFieldType; t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		switch t.Elem().Kind() {
+		case reflect.Float32:
+			format = "FLOAT32"
+		case reflect.Float64:
+			format = "FLOAT64"
+		case reflect.Int8, reflect.Uint8:
+			format = "INT8"
+		default:
+		}
+	}
+	return fmt.Sprintf("VECTOR(%s, %s)", dimension, format)
+}
+
+// isVectorField reports whether the field is mapped to VECTOR data type
+func isVectorField(field *schema.Field) bool {
+	return field != nil && (field.DataType == VectorDataType || strings.EqualFold(string(field.DataType), "VECTOR"))
+}
+
+// checkVectorFields returns ErrVectorUnsupported if any field is VECTOR and the database doesn't support it
+func (d Dialector) checkVectorFields(fields ...*schema.Field) error {
+	if d.capabilities().Vector {
+		return nil
+	}
+	for _, field := range fields {
+		if isVectorField(field) {
+			return fmt.Errorf("%w: field %s", ErrVectorUnsupported, field.Name)
+		}
+	}
+	return nil
+}
+
+// VectorDistanceMetric is the metric of VECTOR_DISTANCE
+type VectorDistanceMetric string
+
+//goland:noinspection GoUnusedConst
+const (
+	Cosine           VectorDistanceMetric = "COSINE"
+	Dot              VectorDistanceMetric = "DOT"
+	Euclidean        VectorDistanceMetric = "EUCLIDEAN"
+	EuclideanSquared VectorDistanceMetric = "EUCLIDEAN_SQUARED"
+	Hamming          VectorDistanceMetric = "HAMMING"
+	Manhattan        VectorDistanceMetric = "MANHATTAN"
+)
+
+// VectorDistanceExpression is the VECTOR_DISTANCE expression of a VECTOR column and a vector
+//
+//	db.Order(oracle.VectorDistance("embedding", query, oracle.Cosine).OrderBy()).Limit(5).Find(&documents)
+//	// SELECT * FROM "documents" ORDER BY VECTOR_DISTANCE("embedding", :1, COSINE) FETCH NEXT 5 ROWS ONLY
+//
+//	db.Where("? < ?", oracle.VectorDistance("embedding", query, oracle.Cosine), 0.2).Find(&documents)
+type VectorDistanceExpression struct {
+	Column string
+	Vector interface{}
+	// Metric defaults to COSINE if it's empty
+	Metric VectorDistanceMetric
+}
+
+// VectorDistance returns the VECTOR_DISTANCE expression of the column and vector
+func VectorDistance(column string, vector interface{}, metric VectorDistanceMetric) VectorDistanceExpression {
+	return VectorDistanceExpression{Column: column, Vector: vector, Metric: metric}
+}
+
+// Build implements clause.Expression
+func (v VectorDistanceExpression) Build(builder clause.Builder) {
+	_, _ = builder.WriteString("VECTOR_DISTANCE(")
+	builder.WriteQuoted(v.Column)
+	_, _ = builder.WriteString(", ")
+	vector := v.Vector
+	// the slices would be expanded into a list of binds
+	switch data := vector.(type) {
+	case []float32:
+		vector = Vector[float32](data)
+	case []float64:
+		vector = Vector[float64](data)
+	case []int8:
+		vector = Vector[int8](data)
+	}
+	builder.AddVar(builder, vector)
+	if v.Metric != "" {
+		_, _ = builder.WriteString(", ")
+		_, _ = builder.WriteString(string(v.Metric))
+	}
+	_ = builder.WriteByte(')')
+}
+
+// OrderBy returns the ORDER BY clause of the distance in ascending order, the nearest first
+func (v VectorDistanceExpression) OrderBy() clause.OrderBy {
+	return clause.OrderBy{Expression: v}
+}
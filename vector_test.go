@@ -0,0 +1,148 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sijms/go-ora/v2"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+type testVectorDocument struct {
+	ID        uint64          `gorm:"primaryKey"`
+	Content   string          `gorm:"size:200"`
+	Embedding Vector[float32] `gorm:"size:3;index:idx_doc_embedding,class:VECTOR,type:HNSW,option:DISTANCE COSINE WITH TARGET ACCURACY 95"`
+	Binary    Vector[int8]    `gorm:"size:3"`
+	Flexible  Vector[float64]
+}
+
+func (testVectorDocument) TableName() string {
+	return "test_vector_document"
+}
+
+func TestVector(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		if got := (Vector[float32]{1.5, 2, -3}).String(); got != "[1.5,2,-3]" {
+			t.Errorf("String() = %s", got)
+		}
+		if got := (Vector[int8]{-1, 2}).String(); got != "[-1,2]" {
+			t.Errorf("String() = %s", got)
+		}
+	})
+	t.Run("Scan", func(t *testing.T) {
+		tests := []struct {
+			src  interface{}
+			want Vector[float32]
+		}{
+			{src: nil, want: nil},
+			{src: []float32{1, 2}, want: Vector[float32]{1, 2}},
+			{src: []float64{1.5, -2}, want: Vector[float32]{1.5, -2}},
+			{src: go_ora.Vector{Data: []float32{3}}, want: Vector[float32]{3}},
+			{src: []uint8{255, 128, 1}, want: Vector[float32]{-1, -128, 1}},
+			{src: go_ora.Vector{Data: []uint8{254}}, want: Vector[float32]{-2}},
+			{src: "[1,2.5]", want: Vector[float32]{1, 2.5}},
+		}
+		for _, tt := range tests {
+			var got Vector[float32]
+			if err := got.Scan(tt.src); err != nil || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan(%v) = %v, %v, want %v", tt.src, got, err, tt.want)
+			}
+		}
+
+		var int8s Vector[int8]
+		if err := int8s.Scan([]uint8{255, 1}); err != nil || !reflect.DeepEqual(int8s, Vector[int8]{-1, 1}) {
+			t.Errorf("Scan() = %v, %v", int8s, err)
+		}
+		var float64s Vector[float64]
+		if err := float64s.Scan([]uint8{255, 127}); err != nil || !reflect.DeepEqual(float64s, Vector[float64]{-1, 127}) {
+			t.Errorf("Scan() = %v, %v", float64s, err)
+		}
+	})
+	t.Run("Bind", func(t *testing.T) {
+		value, err := Vector[int8]{-1, 1}.oracleVector()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if vector, ok := value.(go_ora.Vector); !ok || !reflect.DeepEqual(vector.Data, []uint8{255, 1}) {
+			t.Errorf("oracleVector() = %#v", value)
+		}
+		if value, _ = Vector[float32](nil).oracleVector(); value != nil {
+			t.Errorf("oracleVector() of nil = %#v, want nil", value)
+		}
+	})
+}
+
+func TestGoldenVector(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "23.4.0.24.05")
+	if err := db.Migrator().CreateTable(&testVectorDocument{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&[]testVectorDocument{
+		{Content: "a", Embedding: Vector[float32]{1, 2, 3}, Binary: Vector[int8]{1, -1, 0}},
+		{Content: "b", Embedding: Vector[float32]{4, 5, 6}},
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+	var documents []testVectorDocument
+	query := []float32{1, 1, 1}
+	if err := db.Where("? < ?", VectorDistance("embedding", query, Cosine), 0.5).
+		Order(VectorDistance("embedding", query, Cosine).OrderBy()).
+		Limit(5).Find(&documents).Error; err != nil {
+		t.Fatal(err)
+	}
+	assertGolden(t, recorder)
+
+	got := db.Dialector.Explain(`SELECT VECTOR_DISTANCE("embedding", :1) FROM DUAL`, Vector[float32]{1, 2}.GormValue(nil, nil).Vars[0])
+	if want := `SELECT VECTOR_DISTANCE("embedding", '[1,2]') FROM DUAL`; got != want {
+		t.Errorf("Explain() = %s, want %s", got, want)
+	}
+}
+
+func TestVectorUnsupported(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "19.0.0.0.0")
+	if err := db.Migrator().CreateTable(&testVectorDocument{}); !errors.Is(err, ErrVectorUnsupported) {
+		t.Errorf("CreateTable() error = %v, want %v", err, ErrVectorUnsupported)
+	}
+	if err := db.Migrator().CreateIndex(&testVectorDocument{}, "idx_doc_embedding"); !errors.Is(err, ErrVectorUnsupported) {
+		t.Errorf("CreateIndex() error = %v, want %v", err, ErrVectorUnsupported)
+	}
+	if got := recorder.Statements(); len(got) != 0 {
+		t.Errorf("the rejected migration executed statements: %v", got)
+	}
+}
+
+func TestVectorAutoMigrate(t *testing.T) {
+	db, recorder := openRecorder(t, Config{NamingCaseSensitive: true}, "23.4.0.24.05")
+	// the table created by the first AutoMigrate
+	recorder.Script(`FROM USER_TABLES`, oracletest.Result{Columns: []string{"COUNT"}, Rows: [][]driver.Value{{int64(1)}}})
+	recorder.Script(`FROM USER_INDEXES`, oracletest.Result{Columns: []string{"COUNT"}, Rows: [][]driver.Value{{int64(1)}}})
+	recorder.Script(`FROM USER_TAB_COLUMNS`, oracletest.Result{Columns: []string{"COUNT"}, Rows: [][]driver.Value{{int64(1)}}})
+	recorder.Script(`FROM ALL_TAB_COLUMNS`, oracletest.Result{
+		Columns: []string{"COLUMN_NAME", "DATA_TYPE", "DATA_LENGTH", "CHAR_LENGTH", "CHAR_USED",
+			"DATA_PRECISION", "DATA_SCALE", "NULLABLE", "DATA_DEFAULT", "COMMENTS", "IDENTITY_COLUMN"},
+		Rows: [][]driver.Value{
+			{"id", "NUMBER", int64(22), int64(0), nil, nil, int64(0), "N", nil, nil, "NO"},
+			{"content", "VARCHAR2", int64(200), int64(200), "B", nil, nil, "Y", nil, nil, "NO"},
+			{"embedding", "VECTOR", int64(8200), int64(0), nil, nil, nil, "Y", nil, nil, "NO"},
+			{"binary", "VECTOR", int64(8200), int64(0), nil, nil, nil, "Y", nil, nil, "NO"},
+			{"flexible", "VECTOR", int64(8200), int64(0), nil, nil, nil, "Y", nil, nil, "NO"},
+		},
+	})
+	recorder.Script(`FROM ALL_CONSTRAINTS`, oracletest.Result{
+		Columns: []string{"COLUMN_NAME", "CONSTRAINT_TYPE", "COUNT"},
+		Rows:    [][]driver.Value{{"id", "P", int64(1)}},
+	})
+
+	if err := db.Migrator().AutoMigrate(&testVectorDocument{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range recorder.Statements() {
+		if !stmt.Query && strings.HasPrefix(stmt.SQL, "ALTER") {
+			t.Errorf("AutoMigrate() of the migrated table executed %s", stmt.SQL)
+		}
+	}
+}
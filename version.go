@@ -0,0 +1,110 @@
+package oracle
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ServerVersion is the version of the database server, e.g. 19.3.0 for "19.3.0.0.0"
+type ServerVersion struct {
+	Major   int
+	Minor   int
+	Release int
+}
+
+var (
+	// e.g. "Version 23.4.0.24.05" of V$VERSION.BANNER_FULL since Oracle 18c
+	fullVersionPattern = regexp.MustCompile(`(?i)\bVersion\s+(\d+(?:\.\d+)+)`)
+	// e.g. "19.0.0.0.0" or "Release 11.2.0.4.0"
+	dottedVersionPattern = regexp.MustCompile(`\d+(?:\.\d+)+`)
+	// e.g. "Release 19c", "Oracle Database 11g" or "23ai"
+	namedVersionPattern = regexp.MustCompile(`(?i)\b(\d+)\s*(?:ai|c|g|i)?\b`)
+)
+
+// ParseServerVersion parses the version string of the database server,
+// such as "23.4.0.24.05", "Release 19c" or the banner of V$VERSION
+func ParseServerVersion(version string) (v ServerVersion, ok bool) {
+	var text string
+	if matches := fullVersionPattern.FindStringSubmatch(version); matches != nil {
+		text = matches[1]
+	} else if text = dottedVersionPattern.FindString(version); text == "" {
+		if matches = namedVersionPattern.FindStringSubmatch(version); matches != nil {
+			text = matches[1]
+		}
+	}
+	if text == "" {
+		return
+	}
+
+	numbers := strings.Split(text, ".")
+	for idx, dest := range []*int{&v.Major, &v.Minor, &v.Release} {
+		if idx < len(numbers) {
+			*dest, _ = strconv.Atoi(numbers[idx])
+		}
+	}
+	return v, v.Major > 0
+}
+
+// String returns the version in major.minor.release format
+func (v ServerVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Release)
+}
+
+// AtLeast reports whether the version is at least major.minor
+func (v ServerVersion) AtLeast(major, minor int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	return v.Minor >= minor
+}
+
+// less reports whether the version is lower than o
+func (v ServerVersion) less(o ServerVersion) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Release < o.Release
+}
+
+// ServerVersion returns the parsed DBVer, which is zero if the version is unknown
+func (d Dialector) ServerVersion() (v ServerVersion) {
+	if d.Config != nil {
+		v, _ = ParseServerVersion(d.DBVer)
+	}
+	return
+}
+
+// serverVersionQueries are the queries of the server version, in the order they are tried,
+// some of the views may be inaccessible for the user or behind a proxy
+//
+//goland:noinspection SqlNoDataSourceInspection
+var serverVersionQueries = []string{
+	"select version from product_component_version where rownum = 1",
+	"SELECT BANNER FROM V$VERSION WHERE BANNER LIKE 'Oracle%' AND ROWNUM = 1",
+	"SELECT VERSION FROM V$INSTANCE",
+}
+
+// detectServerVersion detects the version of the database server, it tries product_component_version,
+// V$VERSION and V$INSTANCE in order, and returns an empty string if all of them fail,
+// then Config.DBVer is used as it is
+func (d Dialector) detectServerVersion(db *gorm.DB) string {
+	ctx := context.Background()
+	var version sql.NullString
+	for _, query := range serverVersionQueries {
+		if db.ConnPool.QueryRowContext(ctx, query).Scan(&version) == nil {
+			if _, ok := ParseServerVersion(version.String); ok {
+				return version.String
+			}
+		}
+	}
+	return ""
+}
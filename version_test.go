@@ -0,0 +1,91 @@
+package oracle
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/godoes/gorm-oracle/oracletest"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    ServerVersion
+		ok      bool
+	}{
+		{version: "23.4.0.24.05", want: ServerVersion{Major: 23, Minor: 4}, ok: true},
+		{version: "19.3.0.0.0", want: ServerVersion{Major: 19, Minor: 3}, ok: true},
+		{version: "12.2.0.1.0", want: ServerVersion{Major: 12, Minor: 2, Release: 0}, ok: true},
+		{version: "11.2.0.4.0", want: ServerVersion{Major: 11, Minor: 2}, ok: true},
+		{version: "Release 19c", want: ServerVersion{Major: 19}, ok: true},
+		{version: "Oracle Database 23ai Free", want: ServerVersion{Major: 23}, ok: true},
+		{version: "Oracle Database 11g Enterprise Edition Release 11.2.0.4.0 - 64bit Production",
+			want: ServerVersion{Major: 11, Minor: 2}, ok: true},
+		{version: "Oracle Database 23ai Free Release 23.0.0.0.0 - Develop, Learn, and Run for Free\nVersion 23.4.0.24.05",
+			want: ServerVersion{Major: 23, Minor: 4}, ok: true},
+		{version: "", ok: false},
+		{version: "unknown", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, ok := ParseServerVersion(tt.version)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("ParseServerVersion() = %v, %v, want %v, %v", got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestDetectServerVersion(t *testing.T) {
+	denied := oracletest.Result{Err: errors.New("ORA-00942: table or view does not exist")}
+	tests := []struct {
+		name    string
+		scripts map[string]oracletest.Result
+		dbVer   string
+		want    string
+	}{
+		{name: "ProductComponentVersion", want: "19.0.0.0.0"},
+		{name: "Banner", scripts: map[string]oracletest.Result{
+			`product_component_version`: denied,
+			`V\$VERSION`: {Columns: []string{"BANNER"}, Rows: [][]driver.Value{
+				{"Oracle Database 11g Enterprise Edition Release 11.2.0.4.0 - 64bit Production"},
+			}},
+		}, want: "Oracle Database 11g Enterprise Edition Release 11.2.0.4.0 - 64bit Production"},
+		{name: "Instance", scripts: map[string]oracletest.Result{
+			`product_component_version`: denied,
+			`V\$VERSION`:                denied,
+			`V\$INSTANCE`:               {Columns: []string{"VERSION"}, Rows: [][]driver.Value{{"23.4.0.24.05"}}},
+		}, want: "23.4.0.24.05"},
+		{name: "Config", scripts: map[string]oracletest.Result{
+			`product_component_version|V\$VERSION|V\$INSTANCE`: denied,
+		}, dbVer: "12.1.0.2.0", want: "12.1.0.2.0"},
+		{name: "Unknown", scripts: map[string]oracletest.Result{
+			`product_component_version|V\$VERSION|V\$INSTANCE`: denied,
+		}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := oracletest.NewRecorder()
+			for pattern, result := range tt.scripts {
+				recorder.Script(pattern, result)
+			}
+			dialector := New(Config{Conn: recorder.DB(), DBVer: tt.dbVer})
+			db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			got := ptrDereference(db.Dialector).(Dialector)
+			if got.DBVer != tt.want {
+				t.Errorf("DBVer = %q, want %q", got.DBVer, tt.want)
+			}
+			v, _ := ParseServerVersion(tt.want)
+			if got.ServerVersion() != v {
+				t.Errorf("ServerVersion() = %v, want %v", got.ServerVersion(), v)
+			}
+		})
+	}
+}